@@ -0,0 +1,274 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+)
+
+// AATree is an Arne Andersson tree, a variant of the red black tree where red
+// nodes can only be added as right children. Instead of colors, nodes store
+// integer levels, and balance is restored with two operations: skew and split.
+type AATree[T cmp.Ordered] struct {
+	root *AANode[T]
+	size int
+}
+
+// NewAATree returns an initialized AA tree.
+func NewAATree[T cmp.Ordered]() *AATree[T] {
+	return &AATree[T]{
+		size: 0,
+		root: nil,
+	}
+}
+
+func (t *AATree[T]) Root() Node[T] {
+	panicIfNilTree(t)
+
+	if t.root == nil {
+		return nil
+	}
+	return t.root
+}
+
+func (t *AATree[T]) Size() int {
+	panicIfNilTree(t)
+
+	return t.size
+}
+
+func (t *AATree[T]) Count(value T) int {
+	panicIfNilTree(t)
+
+	c := t.root
+	for c != nil {
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return c.Count()
+		}
+	}
+	return 0
+}
+
+func (t *AATree[T]) Insert(value T) error {
+	panicIfNilTree(t)
+
+	root, err := aaInsert(t.root, value)
+	if err != nil {
+		return err
+	}
+	t.root = root
+	t.root.parent = nil
+	t.size++
+	return nil
+}
+
+func (t *AATree[T]) Delete(value T) error {
+	panicIfNilTree(t)
+
+	// the recursive deletion assumes the value is present.
+	if t.Count(value) == 0 {
+		return errors.New("value not found")
+	}
+
+	t.root = aaDelete(t.root, value)
+	if t.root != nil {
+		t.root.parent = nil
+	}
+	t.size--
+	return nil
+}
+
+func (t *AATree[T]) String() string {
+	panicIfNilTree(t)
+
+	return FormatTree(t, string(FormatHorizontal))
+}
+
+type AANode[T cmp.Ordered] struct {
+	parent *AANode[T]
+	left   *AANode[T]
+	right  *AANode[T]
+	value  T
+	// level is the number of left links on the path to a nil node. Leaves have
+	// level 1.
+	level int
+}
+
+func (n *AANode[T]) Parent() Node[T] {
+	panicIfNilNode(n)
+
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *AANode[T]) Left() Node[T] {
+	panicIfNilNode(n)
+
+	if n.left == nil {
+		return nil
+	}
+	return n.left
+}
+
+func (n *AANode[T]) Right() Node[T] {
+	panicIfNilNode(n)
+
+	if n.right == nil {
+		return nil
+	}
+	return n.right
+}
+
+func (n *AANode[T]) Value() T {
+	panicIfNilNode(n)
+
+	return n.value
+}
+
+func (n *AANode[T]) Count() int {
+	panicIfNilNode(n)
+
+	return 1
+}
+
+// ttylevel is used to print the level of the node.
+func (n *AANode[T]) ttylevel() int {
+	panicIfNilNode(n)
+
+	return n.level
+}
+
+// Tree helpers
+//
+// The helpers below work on subtrees and return the new root of the subtree
+// they were called on. Callers are responsible for linking that root to its
+// parent.
+
+func aaLevel[T cmp.Ordered](n *AANode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.level
+}
+
+func aaSetLeft[T cmp.Ordered](h *AANode[T], l *AANode[T]) {
+	h.left = l
+	if l != nil {
+		l.parent = h
+	}
+}
+
+func aaSetRight[T cmp.Ordered](h *AANode[T], r *AANode[T]) {
+	h.right = r
+	if r != nil {
+		r.parent = h
+	}
+}
+
+// aaSkew removes a left horizontal link by rotating right.
+func aaSkew[T cmp.Ordered](h *AANode[T]) *AANode[T] {
+	if h == nil || h.left == nil || h.left.level != h.level {
+		return h
+	}
+	l := h.left
+	l.parent = h.parent
+	aaSetLeft(h, l.right)
+	aaSetRight(l, h)
+	return l
+}
+
+// aaSplit removes two consecutive right horizontal links by rotating left and
+// increasing the level of the middle node.
+func aaSplit[T cmp.Ordered](h *AANode[T]) *AANode[T] {
+	if h == nil || h.right == nil || h.right.right == nil || h.right.right.level != h.level {
+		return h
+	}
+	r := h.right
+	r.parent = h.parent
+	aaSetRight(h, r.left)
+	aaSetLeft(r, h)
+	r.level++
+	return r
+}
+
+func aaInsert[T cmp.Ordered](h *AANode[T], value T) (*AANode[T], error) {
+	if h == nil {
+		return &AANode[T]{value: value, level: 1}, nil
+	}
+
+	if value < h.value {
+		l, err := aaInsert(h.left, value)
+		if err != nil {
+			return h, err
+		}
+		aaSetLeft(h, l)
+	} else if value > h.value {
+		r, err := aaInsert(h.right, value)
+		if err != nil {
+			return h, err
+		}
+		aaSetRight(h, r)
+	} else {
+		return h, errors.New("value already exists")
+	}
+
+	return aaSplit(aaSkew(h)), nil
+}
+
+// aaDelete removes value from the subtree rooted at h, which must contain it.
+func aaDelete[T cmp.Ordered](h *AANode[T], value T) *AANode[T] {
+	if value < h.value {
+		aaSetLeft(h, aaDelete(h.left, value))
+	} else if value > h.value {
+		aaSetRight(h, aaDelete(h.right, value))
+	} else {
+		if h.left == nil && h.right == nil {
+			return nil
+		}
+
+		// replace h with its successor or predecessor. Nodes are relinked
+		// rather than having their values swapped, so that nodes handed out to
+		// callers keep storing the same value.
+		var m *AANode[T]
+		if h.left == nil {
+			m = h.right
+			for m.left != nil {
+				m = m.left
+			}
+			aaSetRight(h, aaDelete(h.right, m.value))
+		} else {
+			m = h.left
+			for m.right != nil {
+				m = m.right
+			}
+			aaSetLeft(h, aaDelete(h.left, m.value))
+		}
+		aaSetLeft(m, h.left)
+		aaSetRight(m, h.right)
+		m.level = h.level
+		m.parent = h.parent
+		h = m
+	}
+
+	// decrease the level of h if one of its children is too low, then skew
+	// and split the whole level.
+	if should := min(aaLevel(h.left), aaLevel(h.right)) + 1; should < h.level {
+		h.level = should
+		if h.right != nil && should < h.right.level {
+			h.right.level = should
+		}
+	}
+	h = aaSkew(h)
+	aaSetRight(h, aaSkew(h.right))
+	if h.right != nil {
+		aaSetRight(h.right, aaSkew(h.right.right))
+	}
+	h = aaSplit(h)
+	aaSetRight(h, aaSplit(h.right))
+	return h
+}
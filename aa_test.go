@@ -0,0 +1,82 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkAA verifies the AA tree invariants of the subtree rooted at n.
+func checkAA(t *testing.T, n *AANode[int]) {
+	t.Helper()
+
+	if n == nil {
+		return
+	}
+	if n.left == nil && n.right == nil && n.level != 1 {
+		t.Fatalf("leaf %d has level %d", n.value, n.level)
+	}
+	if aaLevel(n.left) != n.level-1 {
+		t.Fatalf("left child of %d has level %d, expected %d", n.value, aaLevel(n.left), n.level-1)
+	}
+	if l := aaLevel(n.right); l != n.level && l != n.level-1 {
+		t.Fatalf("right child of %d has level %d, expected %d or %d", n.value, l, n.level, n.level-1)
+	}
+	if n.right != nil && aaLevel(n.right.right) >= n.level {
+		t.Fatalf("node %d has two consecutive horizontal links", n.value)
+	}
+	if n.level > 1 && (n.left == nil || n.right == nil) {
+		t.Fatalf("node %d has level %d but is missing a child", n.value, n.level)
+	}
+	checkAA(t, n.left)
+	checkAA(t, n.right)
+}
+
+func TestAAInvariants(t *testing.T) {
+	r := rand.New(rand.NewSource(13))
+	tr := NewAATree[int]()
+
+	for _, v := range r.Perm(500) {
+		tr.Insert(v)
+		checkAA(t, tr.root)
+	}
+	checkParentLinks[int](t, tr.Root())
+
+	for _, v := range r.Perm(500)[:400] {
+		if err := tr.Delete(v); err != nil {
+			t.Fatalf("expected %d to be in tree, got error %s", v, err.Error())
+		}
+		checkAA(t, tr.root)
+		checkParentLinks[int](t, tr.Root())
+	}
+	if tr.Size() != 100 {
+		t.Errorf("expected 100 elements, got %d", tr.Size())
+	}
+}
+
+func TestAALevelOutput(t *testing.T) {
+	tr := NewAATree[int]()
+	for _, v := range []int{1, 2, 3, 4} {
+		tr.Insert(v)
+	}
+
+	linux := `
+2[2]
+├── 1[1]
+└── 3[1]
+    ├── *
+    └── 4[1]`
+	if out := FormatTree[int](tr, FormatLinuxTree); out != linux[1:] {
+		t.Errorf("expected:\n%s\ngot:\n%s", linux[1:], out)
+	}
+
+	horizontal := "" +
+		"   2[2]     \n" +
+		"   / \\      \n" +
+		"  /   \\     \n" +
+		"1[1]  3[1]  \n" +
+		"        \\   \n" +
+		"        4[1]\n"
+	if out := FormatTree[int](tr, FormatHorizontal); out != horizontal {
+		t.Errorf("expected:\n%q\ngot:\n%q", horizontal, out)
+	}
+}
@@ -0,0 +1,316 @@
+package tree
+
+import "cmp"
+
+// Aggregator describes how values are aggregated over ranges of a tree.
+//
+// Combine must be associative, but doesn't have to be commutative: it is
+// always called with the aggregate of the smaller values as its first
+// argument. There is no need for an identity element, since empty ranges are
+// reported separately.
+type Aggregator[T, A any] struct {
+	// Lift returns the aggregate of a single value.
+	Lift func(value T) A
+	// Combine returns the aggregate of two adjacent ranges, where a is the
+	// aggregate of the range storing smaller values.
+	Combine func(a, b A) A
+}
+
+type number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+type integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// SumAggregator aggregates values by adding them up.
+func SumAggregator[T number]() Aggregator[T, T] {
+	return Aggregator[T, T]{
+		Lift:    func(value T) T { return value },
+		Combine: func(a, b T) T { return a + b },
+	}
+}
+
+// MinAggregator aggregates values by keeping the smallest one.
+func MinAggregator[T cmp.Ordered]() Aggregator[T, T] {
+	return Aggregator[T, T]{
+		Lift:    func(value T) T { return value },
+		Combine: func(a, b T) T { return min(a, b) },
+	}
+}
+
+// MaxAggregator aggregates values by keeping the largest one.
+func MaxAggregator[T cmp.Ordered]() Aggregator[T, T] {
+	return Aggregator[T, T]{
+		Lift:    func(value T) T { return value },
+		Combine: func(a, b T) T { return max(a, b) },
+	}
+}
+
+// XorAggregator aggregates values by computing their bitwise xor.
+func XorAggregator[T integer]() Aggregator[T, T] {
+	return Aggregator[T, T]{
+		Lift:    func(value T) T { return value },
+		Combine: func(a, b T) T { return a ^ b },
+	}
+}
+
+// Augmented is a red black tree where every node also stores the aggregate of
+// the values in its subtree. Aggregates are maintained through insertions,
+// deletions and rotations, which allows computing the aggregate of any range of
+// values in O(lg n) time.
+type Augmented[T, A any] struct {
+	t   *RBT[augEntry[T, A]]
+	agg Aggregator[T, A]
+	cmp func(a, b T) int
+}
+
+// augEntry is the value stored in the nodes of the underlying tree. Entries
+// are only ordered by value, which allows updating the aggregate in place.
+type augEntry[T, A any] struct {
+	value T
+	agg   A
+}
+
+// NewAugmented returns an initialized augmented tree that aggregates values
+// using agg.
+func NewAugmented[T cmp.Ordered, A any](agg Aggregator[T, A]) *Augmented[T, A] {
+	return NewAugmentedFunc(cmp.Compare[T], agg)
+}
+
+// NewAugmentedFunc is like NewAugmented, but orders values using cmp. See
+// NewRBTFunc.
+func NewAugmentedFunc[T, A any](cmp func(a, b T) int, agg Aggregator[T, A]) *Augmented[T, A] {
+	t := NewRBTFunc(func(a, b augEntry[T, A]) int {
+		return cmp(a.value, b.value)
+	})
+	t.augment = func(x *rbtNode[augEntry[T, A]]) {
+		a := agg.Lift(x.value.value)
+		if x.left != nil {
+			a = agg.Combine(x.left.value.agg, a)
+		}
+		if x.right != nil {
+			a = agg.Combine(a, x.right.value.agg)
+		}
+		x.value.agg = a
+	}
+	return &Augmented[T, A]{
+		t:   t,
+		agg: agg,
+		cmp: cmp,
+	}
+}
+
+func (t *Augmented[T, A]) Root() Node[T] {
+	panicIfNilTree(t)
+
+	if t.t.root == nil {
+		return nil
+	}
+	return &AugmentedNode[T, A]{n: t.t.root}
+}
+
+func (t *Augmented[T, A]) Size() int {
+	panicIfNilTree(t)
+
+	return t.t.Size()
+}
+
+func (t *Augmented[T, A]) Count(value T) int {
+	panicIfNilTree(t)
+
+	return t.t.Count(augEntry[T, A]{value: value})
+}
+
+func (t *Augmented[T, A]) Insert(value T) error {
+	panicIfNilTree(t)
+
+	return t.t.Insert(augEntry[T, A]{value: value})
+}
+
+func (t *Augmented[T, A]) Delete(value T) error {
+	panicIfNilTree(t)
+
+	return t.t.Delete(augEntry[T, A]{value: value})
+}
+
+func (t *Augmented[T, A]) String() string {
+	panicIfNilTree(t)
+
+	return FormatTree(t, string(FormatHorizontal))
+}
+
+// Modifications returns the number of times the tree was modified. Iterators
+// over the tree can detect modifications during iteration by comparing it
+// before and after yielding, as All does.
+func (t *Augmented[T, A]) Modifications() int {
+	panicIfNilTree(t)
+
+	return t.t.mods
+}
+
+func (t *Augmented[T, A]) modifications() int {
+	return t.Modifications()
+}
+
+// Aggregate returns the aggregate of the values between lo and hi, with each
+// end configured by opts as in Range. The boolean result is false if there are
+// no values in the range.
+func (t *Augmented[T, A]) Aggregate(lo, hi T, opts RangeOptions) (A, bool) {
+	panicIfNilTree(t)
+
+	var zero A
+	aboveLo := func(x *rbtNode[augEntry[T, A]]) bool {
+		switch opts.Lo {
+		case Exclusive:
+			return t.cmp(x.value.value, lo) > 0
+		case Unbounded:
+			return true
+		default:
+			return t.cmp(x.value.value, lo) >= 0
+		}
+	}
+	belowHi := func(x *rbtNode[augEntry[T, A]]) bool {
+		switch opts.Hi {
+		case Exclusive:
+			return t.cmp(x.value.value, hi) < 0
+		case Unbounded:
+			return true
+		default:
+			return t.cmp(x.value.value, hi) <= 0
+		}
+	}
+
+	// find the highest node within the range. All values in the range are
+	// stored in its subtree.
+	x := t.t.root
+	for x != nil {
+		if !aboveLo(x) {
+			x = x.right
+		} else if !belowHi(x) {
+			x = x.left
+		} else {
+			break
+		}
+	}
+	if x == nil {
+		return zero, false
+	}
+
+	res := t.agg.Lift(x.value.value)
+
+	// every value in the left subtree is below hi, so only the lower end has
+	// to be checked. Whenever a node is within the range, so is its right
+	// subtree.
+	var left A
+	leftok := false
+	for n := x.left; n != nil; {
+		if aboveLo(n) {
+			a := t.agg.Lift(n.value.value)
+			if n.right != nil {
+				a = t.agg.Combine(a, n.right.value.agg)
+			}
+			if leftok {
+				a = t.agg.Combine(a, left)
+			}
+			left, leftok = a, true
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	if leftok {
+		res = t.agg.Combine(left, res)
+	}
+
+	// symmetric for the right subtree.
+	for n := x.right; n != nil; {
+		if belowHi(n) {
+			if n.left != nil {
+				res = t.agg.Combine(res, n.left.value.agg)
+			}
+			res = t.agg.Combine(res, t.agg.Lift(n.value.value))
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+
+	return res, true
+}
+
+// AugmentedNode is a node of an augmented tree. Besides the operations of
+// Node, it exposes the aggregate of its subtree.
+type AugmentedNode[T, A any] RBTNode[augEntry[T, A]]
+
+func (n *AugmentedNode[T, A]) rbt() *RBTNode[augEntry[T, A]] {
+	return (*RBTNode[augEntry[T, A]])(n)
+}
+
+func (n *AugmentedNode[T, A]) Value() T {
+	panicIfNilRBTNode(n.rbt())
+
+	return n.n.value.value
+}
+
+func (n *AugmentedNode[T, A]) Count() int {
+	panicIfNilRBTNode(n.rbt())
+
+	return n.n.count
+}
+
+// Aggregate returns the aggregate of all values in the subtree rooted at this
+// node.
+func (n *AugmentedNode[T, A]) Aggregate() A {
+	panicIfNilRBTNode(n.rbt())
+
+	return n.n.value.agg
+}
+
+func (n *AugmentedNode[T, A]) SubtreeSize() int {
+	panicIfNilRBTNode(n.rbt())
+
+	return n.n.size
+}
+
+func (n *AugmentedNode[T, A]) Parent() Node[T] {
+	panicIfNilRBTNode(n.rbt())
+
+	if n.parent == nil {
+		return nil
+	}
+	return (*AugmentedNode[T, A])(n.parent)
+}
+
+func (n *AugmentedNode[T, A]) Left() Node[T] {
+	panicIfNilRBTNode(n.rbt())
+
+	if n.n.left == nil {
+		return nil
+	}
+	return &AugmentedNode[T, A]{n: n.n.left, parent: n.rbt()}
+}
+
+func (n *AugmentedNode[T, A]) Right() Node[T] {
+	panicIfNilRBTNode(n.rbt())
+
+	if n.n.right == nil {
+		return nil
+	}
+	return &AugmentedNode[T, A]{n: n.n.right, parent: n.rbt()}
+}
+
+// ttycolor is used for colored terminal output.
+func (n *AugmentedNode[T, A]) ttycolor() string {
+	panicIfNilRBTNode(n.rbt())
+
+	return n.n.color
+}
+
+func (n *AugmentedNode[T, A]) viewed() any {
+	return n.n
+}
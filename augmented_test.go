@@ -0,0 +1,105 @@
+package tree
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestAugmentedAggregate(t *testing.T) {
+	r := rand.New(rand.NewSource(53))
+
+	sum := NewAugmented(SumAggregator[int]())
+	// concatenation isn't commutative, which checks that aggregates are
+	// combined in order.
+	concat := NewAugmented(Aggregator[int, string]{
+		Lift:    func(v int) string { return fmt.Sprint(v, ",") },
+		Combine: func(a, b string) string { return a + b },
+	})
+
+	for range 1000 {
+		v := r.Intn(100)
+		if r.Intn(3) == 0 {
+			sum.Delete(v)
+			concat.Delete(v)
+		} else {
+			sum.Insert(v)
+			concat.Insert(v)
+		}
+	}
+	values := slices.Collect(All[int](sum))
+
+	bounds := []Bound{Inclusive, Exclusive, Unbounded}
+	for lo := -1; lo <= 100; lo += 7 {
+		for hi := lo - 3; hi <= 101; hi += 5 {
+			for _, lob := range bounds {
+				for _, hib := range bounds {
+					opts := RangeOptions{Lo: lob, Hi: hib}
+					expectedSum, expectedConcat := 0, ""
+					for v := range Range[int](sum, lo, hi, opts) {
+						expectedSum += v
+						expectedConcat += fmt.Sprint(v, ",")
+					}
+
+					s, sok := sum.Aggregate(lo, hi, opts)
+					c, cok := concat.Aggregate(lo, hi, opts)
+					if sok != (expectedConcat != "") || cok != sok {
+						t.Fatalf("[%d, %d] %v: unexpected empty range result %t", lo, hi, opts, sok)
+					}
+					if s != expectedSum {
+						t.Errorf("[%d, %d] %v: expected sum %d, got %d", lo, hi, opts, expectedSum, s)
+					}
+					if c != expectedConcat {
+						t.Errorf("[%d, %d] %v: expected %q, got %q", lo, hi, opts, expectedConcat, c)
+					}
+				}
+			}
+		}
+	}
+
+	if root := sum.Root(); root != nil {
+		total := 0
+		for _, v := range values {
+			total += v
+		}
+		if a := root.(*AugmentedNode[int, int]).Aggregate(); a != total {
+			t.Errorf("expected root aggregate %d, got %d", total, a)
+		}
+	}
+}
+
+func TestAugmentedPresets(t *testing.T) {
+	values := []int{5, 3, 9, 1, 7}
+
+	mn := NewAugmented(MinAggregator[int]())
+	mx := NewAugmented(MaxAggregator[int]())
+	xor := NewAugmented(XorAggregator[int]())
+	for _, v := range values {
+		mn.Insert(v)
+		mx.Insert(v)
+		xor.Insert(v)
+	}
+
+	if v, _ := mn.Aggregate(4, 10, RangeOptions{}); v != 5 {
+		t.Errorf("expected min 5, got %d", v)
+	}
+	if v, _ := mx.Aggregate(0, 8, RangeOptions{}); v != 7 {
+		t.Errorf("expected max 7, got %d", v)
+	}
+	if v, _ := xor.Aggregate(0, 0, RangeOptions{Lo: Unbounded, Hi: Unbounded}); v != 5^3^9^1^7 {
+		t.Errorf("expected xor %d, got %d", 5^3^9^1^7, v)
+	}
+	if _, ok := mn.Aggregate(10, 20, RangeOptions{}); ok {
+		t.Errorf("expected empty range above all values")
+	}
+
+	// augmented trees are regular trees as well.
+	checkParentLinks[int](t, mx.Root())
+	if n := Select[int](mx, 2); n == nil || n.Value() != 5 {
+		t.Errorf("expected 5 at position 2, got %v", n)
+	}
+	if out := FormatTree[int](mx, FormatLinuxTree); out == "" {
+		t.Errorf("expected formatted tree")
+	}
+}
@@ -0,0 +1,310 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+)
+
+type AVLTree[T cmp.Ordered] struct {
+	root *AVLNode[T]
+	size int
+}
+
+// NewAVL returns an initialized AVL tree.
+func NewAVL[T cmp.Ordered]() *AVLTree[T] {
+	return &AVLTree[T]{
+		size: 0,
+		root: nil,
+	}
+}
+
+func (t *AVLTree[T]) Root() Node[T] {
+	panicIfNilTree(t)
+
+	if t.root == nil {
+		return nil
+	}
+	return t.root
+}
+
+func (t *AVLTree[T]) Size() int {
+	panicIfNilTree(t)
+
+	return t.size
+}
+
+func (t *AVLTree[T]) Count(value T) int {
+	panicIfNilTree(t)
+
+	c := t.root
+	for c != nil {
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return c.Count()
+		}
+	}
+	return 0
+}
+
+func (t *AVLTree[T]) Insert(value T) error {
+	panicIfNilTree(t)
+
+	var y *AVLNode[T] = nil
+	c := t.root
+	for c != nil {
+		y = c
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return errors.New("value already exists")
+		}
+	}
+
+	z := &AVLNode[T]{
+		parent: y,
+		value:  value,
+		height: 1,
+		size:   1,
+	}
+	if y == nil {
+		t.root = z
+	} else if value < y.value {
+		y.left = z
+	} else {
+		y.right = z
+	}
+
+	avlRebalance(t, y)
+	t.size++
+	return nil
+}
+
+func (t *AVLTree[T]) Delete(value T) error {
+	panicIfNilTree(t)
+
+	// find z
+	z := t.root
+	for z != nil {
+		if value < z.value {
+			z = z.left
+		} else if value > z.value {
+			z = z.right
+		} else {
+			break
+		}
+	}
+	if z == nil {
+		return errors.New("value not found")
+	}
+
+	// p is the lowest node whose subtree height may have changed.
+	var p *AVLNode[T]
+	if z.left == nil {
+		p = z.parent
+		avltransplant(t, z, z.right)
+	} else if z.right == nil {
+		p = z.parent
+		avltransplant(t, z, z.left)
+	} else {
+		y := treeMinimumAvl(z.right)
+		if y.parent == z {
+			p = y
+		} else {
+			p = y.parent
+			avltransplant(t, y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		avltransplant(t, z, y)
+		y.left = z.left
+		y.left.parent = y
+	}
+
+	avlRebalance(t, p)
+	t.size--
+	return nil
+}
+
+func (t *AVLTree[T]) String() string {
+	panicIfNilTree(t)
+
+	return FormatTree(t, string(FormatHorizontal))
+}
+
+type AVLNode[T cmp.Ordered] struct {
+	parent *AVLNode[T]
+	left   *AVLNode[T]
+	right  *AVLNode[T]
+	value  T
+	// height of the subtree rooted at this node, where a leaf has height 1.
+	height int
+	// size is the number of nodes of the subtree rooted at this node.
+	size int
+}
+
+func (n *AVLNode[T]) Parent() Node[T] {
+	panicIfNilNode(n)
+
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *AVLNode[T]) Left() Node[T] {
+	panicIfNilNode(n)
+
+	if n.left == nil {
+		return nil
+	}
+	return n.left
+}
+
+func (n *AVLNode[T]) Right() Node[T] {
+	panicIfNilNode(n)
+
+	if n.right == nil {
+		return nil
+	}
+	return n.right
+}
+
+func (n *AVLNode[T]) Value() T {
+	panicIfNilNode(n)
+
+	return n.value
+}
+
+func (n *AVLNode[T]) Count() int {
+	panicIfNilNode(n)
+
+	return 1
+}
+
+func (n *AVLNode[T]) SubtreeSize() int {
+	panicIfNilNode(n)
+
+	return n.size
+}
+
+// Tree helpers
+
+func avlHeight[T cmp.Ordered](n *AVLNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// avlBalance returns the balance factor of a node. A positive value means the
+// left subtree is taller.
+func avlBalance[T cmp.Ordered](n *AVLNode[T]) int {
+	return avlHeight(n.left) - avlHeight(n.right)
+}
+
+func avlSize[T cmp.Ordered](n *AVLNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func avlUpdate[T cmp.Ordered](n *AVLNode[T]) {
+	n.height = 1 + max(avlHeight(n.left), avlHeight(n.right))
+	n.size = 1 + avlSize(n.left) + avlSize(n.right)
+}
+
+// avlRebalance walks from n up to the root, restoring heights, sizes and the
+// AVL property along the way.
+func avlRebalance[T cmp.Ordered](t *AVLTree[T], n *AVLNode[T]) {
+	for n != nil {
+		avlUpdate(n)
+		if b := avlBalance(n); b > 1 {
+			if avlBalance(n.left) < 0 {
+				avlLeftRotate(t, n.left)
+			}
+			n = avlRightRotate(t, n)
+		} else if b < -1 {
+			if avlBalance(n.right) > 0 {
+				avlRightRotate(t, n.right)
+			}
+			n = avlLeftRotate(t, n)
+		}
+		n = n.parent
+	}
+}
+
+// avlLeftRotate rotates x to the left and returns the node that took its
+// place.
+func avlLeftRotate[T cmp.Ordered](t *AVLTree[T], x *AVLNode[T]) *AVLNode[T] {
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	y.parent = x.parent
+	if x.parent == nil {
+		t.root = y
+	} else if x == x.parent.left {
+		x.parent.left = y
+	} else {
+		x.parent.right = y
+	}
+	y.left = x
+	x.parent = y
+
+	avlUpdate(x)
+	avlUpdate(y)
+	return y
+}
+
+// avlRightRotate rotates y to the right and returns the node that took its
+// place.
+func avlRightRotate[T cmp.Ordered](t *AVLTree[T], y *AVLNode[T]) *AVLNode[T] {
+	x := y.left
+	y.left = x.right
+	if x.right != nil {
+		x.right.parent = y
+	}
+	x.parent = y.parent
+	if y.parent == nil {
+		t.root = x
+	} else if y == y.parent.left {
+		y.parent.left = x
+	} else {
+		y.parent.right = x
+	}
+	x.right = y
+	y.parent = x
+
+	avlUpdate(y)
+	avlUpdate(x)
+	return x
+}
+
+// transplant replaces one subtree with another subtree
+func avltransplant[T cmp.Ordered](t *AVLTree[T], u *AVLNode[T], v *AVLNode[T]) {
+	// u is root
+	if u.parent == nil {
+		t.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+func treeMinimumAvl[T cmp.Ordered](x *AVLNode[T]) *AVLNode[T] {
+	for x.left != nil {
+		x = x.left
+	}
+	return x
+}
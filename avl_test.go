@@ -0,0 +1,54 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkAVL verifies heights, parent links and the AVL balance property of the
+// subtree rooted at n, returning its height.
+func checkAVL(t *testing.T, n *AVLNode[int]) int {
+	t.Helper()
+
+	if n == nil {
+		return 0
+	}
+	if n.left != nil && n.left.parent != n {
+		t.Fatalf("broken parent link on left child of %d", n.value)
+	}
+	if n.right != nil && n.right.parent != n {
+		t.Fatalf("broken parent link on right child of %d", n.value)
+	}
+	lh := checkAVL(t, n.left)
+	rh := checkAVL(t, n.right)
+	if lh-rh > 1 || rh-lh > 1 {
+		t.Fatalf("node %d is unbalanced: left height %d, right height %d", n.value, lh, rh)
+	}
+	if n.height != 1+max(lh, rh) {
+		t.Fatalf("node %d stores height %d, expected %d", n.value, n.height, 1+max(lh, rh))
+	}
+	if n.size != 1+avlSize(n.left)+avlSize(n.right) {
+		t.Fatalf("node %d stores size %d, expected %d", n.value, n.size, 1+avlSize(n.left)+avlSize(n.right))
+	}
+	return n.height
+}
+
+func TestAVLBalanced(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	tr := NewAVL[int]()
+
+	// consecutive values degenerate a regular BST into a list.
+	for i := range 500 {
+		tr.Insert(i)
+		checkAVL(t, tr.root)
+	}
+	for _, v := range r.Perm(500) {
+		if err := tr.Delete(v); err != nil {
+			t.Fatalf("expected %d to be in tree, got error %s", v, err.Error())
+		}
+		checkAVL(t, tr.root)
+	}
+	if tr.Size() != 0 || tr.Root() != nil {
+		t.Errorf("expected empty tree, got size %d", tr.Size())
+	}
+}
@@ -5,48 +5,98 @@ import (
 	"fmt"
 )
 
-type BST[T cmp.Ordered] struct {
-	root *BSTNode[T]
+// BST is a binary search tree, which doesn't rebalance itself.
+//
+// The zero value is not usable, trees must be created with NewBST, NewBSTFunc,
+// NewMultiBST or NewMultiBSTFunc.
+type BST[T any] struct {
+	root *bstNode[T]
 	size int
+	// cmp orders the values stored in the tree.
+	cmp func(a, b T) int
+	// distinct is the number of nodes, which differs from size only for
+	// multisets.
+	distinct int
+	// multi allows storing the same value multiple times.
+	multi bool
+	// owner identifies the nodes the tree may modify, since the others are
+	// shared with its clones.
+	owner *cowOwner
+	// mods counts the modifications of the tree, which allows iterators to
+	// detect modifications during iteration.
+	mods int
 }
 
 // NewBST returns an initialized binary search tree.
 func NewBST[T cmp.Ordered]() *BST[T] {
+	return NewBSTFunc(cmp.Compare[T])
+}
+
+// NewBSTFunc returns an initialized binary search tree that orders its values
+// using cmp, which should return a negative number when a < b, a positive
+// number when a > b and zero when a == b.
+func NewBSTFunc[T any](cmp func(a, b T) int) *BST[T] {
 	return &BST[T]{
 		size: 0,
 		root: nil,
+		cmp:  cmp,
 	}
 }
 
+// NewMultiBST returns an initialized binary search tree that allows storing the
+// same value multiple times. Inserting an existing value increments the count
+// of its node, and deleting it decrements the count before removing the node.
+func NewMultiBST[T cmp.Ordered]() *BST[T] {
+	return NewMultiBSTFunc(cmp.Compare[T])
+}
+
+// NewMultiBSTFunc is like NewMultiBST, but orders values using cmp. See
+// NewBSTFunc.
+func NewMultiBSTFunc[T any](cmp func(a, b T) int) *BST[T] {
+	t := NewBSTFunc(cmp)
+	t.multi = true
+	return t
+}
+
 func (t *BST[T]) Root() Node[T] {
 	panicIfNilTree(t)
 
 	if t.root == nil {
 		return nil
 	}
-	return t.root
+	return &BSTNode[T]{n: t.root}
 }
 
+// Size returns the number of elements in the tree, counting duplicates.
 func (t *BST[T]) Size() int {
 	panicIfNilTree(t)
 
 	return t.size
 }
 
+// Distinct returns the number of distinct values in the tree. This is the same
+// as Size unless the tree was created with NewMultiBST.
+func (t *BST[T]) Distinct() int {
+	panicIfNilTree(t)
+
+	return t.distinct
+}
+
 func (t *BST[T]) Count(value T) int {
 	panicIfNilTree(t)
+	panicIfUninitialized(t.cmp)
 
 	if t.root == nil {
 		return 0
 	}
 	c := t.root
 	for c != nil {
-		if value < c.value {
+		if o := t.cmp(value, c.value); o < 0 {
 			c = c.left
-		} else if value > c.value {
+		} else if o > 0 {
 			c = c.right
 		} else {
-			return c.Count()
+			return c.count
 		}
 	}
 	return 0
@@ -54,92 +104,69 @@ func (t *BST[T]) Count(value T) int {
 
 func (t *BST[T]) Insert(value T) error {
 	panicIfNilTree(t)
-
-	if t.root == nil {
-		t.root = &BSTNode[T]{
-			parent: nil,
-			left:   nil,
-			right:  nil,
-			value:  value,
-		}
-		t.size++
-		return nil
-	}
-
-	var y *BSTNode[T] = nil
-	c := t.root
-	for c != nil {
-		y = c
-		if value < c.value {
-			c = c.left
-		} else if value > c.value {
-			c = c.right
+	panicIfUninitialized(t.cmp)
+	cowPrepare(&t.owner)
+
+	// link points to the child of the last node visited, where value belongs.
+	link := &t.root
+	for *link != nil {
+		c := bstOwn(t, *link)
+		*link = c
+		if o := t.cmp(value, c.value); o < 0 {
+			link = &c.left
+		} else if o > 0 {
+			link = &c.right
+		} else if t.multi {
+			c.count++
+			t.size++
+			t.mods++
+			return nil
 		} else {
 			return fmt.Errorf("value already exists")
 		}
 	}
-	if value < y.value {
-		y.left = &BSTNode[T]{
-			parent: y,
-			left:   nil,
-			right:  nil,
-			value:  value,
-		}
-	} else {
-		y.right = &BSTNode[T]{
-			parent: y,
-			left:   nil,
-			right:  nil,
-			value:  value,
-		}
+	*link = &bstNode[T]{
+		left:  nil,
+		right: nil,
+		value: value,
+		count: 1,
+		owner: t.owner,
 	}
 	t.size++
+	t.distinct++
+	t.mods++
 	return nil
 }
 
 func (t *BST[T]) Delete(value T) error {
 	panicIfNilTree(t)
+	panicIfUninitialized(t.cmp)
+	cowPrepare(&t.owner)
 
 	if t.root == nil {
 		return fmt.Errorf("value not found")
 	}
 
 	// find node with that value
-	z := t.root
-	for z != nil {
-		if value < z.value {
-			z = z.left
-		} else if value > z.value {
-			z = z.right
-		} else if value == z.value {
+	path := []*bstNode[T]{}
+	link := &t.root
+	for *link != nil {
+		z := bstOwn(t, *link)
+		*link = z
+		path = append(path, z)
+		if o := t.cmp(value, z.value); o < 0 {
+			link = &z.left
+		} else if o > 0 {
+			link = &z.right
+		} else {
 			break
 		}
 	}
-	if z == nil {
+	if *link == nil {
 		return fmt.Errorf("value not found")
 	}
 
-	if z.left == nil {
-		transplant(t, z, z.right)
-		t.size--
-		return nil
-	} else if z.right == nil {
-		transplant(t, z, z.left)
-		t.size--
-		return nil
-	}
-
-	y := treeMinimum(z.right)
-	if y.parent != z {
-		transplant(t, y, y.right)
-		y.right = z.right
-		y.right.parent = y
-	}
-	transplant(t, z, y)
-	y.left = z.left
-	y.left.parent = y
-
-	t.size--
+	bstDeleteNode(t, path)
 	return nil
 }
 
@@ -149,11 +176,29 @@ func (t *BST[T]) String() string {
 	return FormatTree(t, string(FormatHorizontal))
 }
 
-type BSTNode[T cmp.Ordered] struct {
+func (t *BST[T]) modifications() int {
+	return t.mods
+}
+
+// bstNode is a node of a BST. Nodes don't link to their parents, so that clones
+// can share them, see Clone.
+type bstNode[T any] struct {
+	left  *bstNode[T]
+	right *bstNode[T]
+	value T
+	// count is the number of occurences of value, which can only exceed 1 in
+	// multisets.
+	count int
+	// owner identifies the trees that may modify the node.
+	owner *cowOwner
+}
+
+// BSTNode is a node of a BST, as reached from the root of the tree. It
+// remembers the node it was reached from, which is its parent, so retrieving
+// the same node twice results in two different BSTNode values.
+type BSTNode[T any] struct {
+	n      *bstNode[T]
 	parent *BSTNode[T]
-	left   *BSTNode[T]
-	right  *BSTNode[T]
-	value  T
 }
 
 func (n *BSTNode[T]) Parent() Node[T] {
@@ -168,54 +213,140 @@ func (n *BSTNode[T]) Parent() Node[T] {
 func (n *BSTNode[T]) Left() Node[T] {
 	panicIfNilNode(n)
 
-	if n.left == nil {
+	if n.n.left == nil {
 		return nil
 	}
-	return n.left
+	return &BSTNode[T]{n: n.n.left, parent: n}
 }
 
 func (n *BSTNode[T]) Right() Node[T] {
 	panicIfNilNode(n)
 
-	if n.right == nil {
+	if n.n.right == nil {
 		return nil
 	}
-	return n.right
+	return &BSTNode[T]{n: n.n.right, parent: n}
 }
 
 func (n *BSTNode[T]) Value() T {
 	panicIfNilNode(n)
 
-	return n.value
+	return n.n.value
 }
 
 func (n *BSTNode[T]) Count() int {
 	panicIfNilNode(n)
 
-	return 1
+	return n.n.count
+}
+
+func (n *BSTNode[T]) viewed() any {
+	return n.n
 }
 
 // Tree helpers
 
-// transplant replaces one subtree with another subtree
-func transplant[T cmp.Ordered](t *BST[T], u *BSTNode[T], v *BSTNode[T]) {
+// bstDeleteNode removes one occurence of the value stored in the last node of
+// path, which leads from the root of the tree through nodes the tree may
+// modify. It returns the path to the node storing the following element, which
+// is the same node if it still stores the value, or an empty path if there is
+// no following element. The returned path may reuse the memory of path.
+func bstDeleteNode[T any](t *BST[T], path []*bstNode[T]) []*bstNode[T] {
+	t.size--
+	t.mods++
+	k := len(path) - 1
+	z := path[k]
+	if z.count > 1 {
+		z.count--
+		return path
+	}
+	t.distinct--
+
+	var zp *bstNode[T]
+	if k > 0 {
+		zp = path[k-1]
+	}
+	if z.right == nil {
+		// the following element is stored in the closest ancestor whose left
+		// subtree contains z.
+		i := k
+		for i > 0 && path[i-1].right == path[i] {
+			i--
+		}
+		transplant(t, zp, z, z.left)
+		return path[:i]
+	} else if z.left == nil {
+		transplant(t, zp, z, z.right)
+		path = path[:k]
+		for x := z.right; x != nil; x = x.left {
+			path = append(path, x)
+		}
+		return path
+	}
+
+	// y is the minimum of the right subtree of z, and takes its place. Nodes on
+	// the way down to it are modified when y isn't the right child of z.
+	z.right = bstOwn(t, z.right)
+	yp, y := z, z.right
+	for y.left != nil {
+		y.left = bstOwn(t, y.left)
+		yp, y = y, y.left
+	}
+	if yp != z {
+		yp.left = y.right
+		y.right = z.right
+	}
+	transplant(t, zp, z, y)
+	y.left = z.left
+	return append(path[:k], y)
+}
+
+// transplant replaces the subtree rooted at u, whose parent is p, with the
+// subtree rooted at v. p must be nil if u is the root.
+func transplant[T any](t *BST[T], p *bstNode[T], u *bstNode[T], v *bstNode[T]) {
 	// u is root
-	if u.parent == nil {
+	if p == nil {
 		t.root = v
-	} else if u == u.parent.left {
-		u.parent.left = v
+	} else if u == p.left {
+		p.left = v
 	} else {
-		u.parent.right = v
+		p.right = v
 	}
-	if v != nil {
-		v.parent = u.parent
+}
+
+// bstView returns a view of the last node of path, which leads from the root
+// of the tree, or nil if path is empty. All views on the path are allocated at
+// once.
+func bstView[T any](path []*bstNode[T]) *BSTNode[T] {
+	if len(path) == 0 {
+		return nil
+	}
+	views := make([]BSTNode[T], len(path))
+	for i, x := range path {
+		views[i].n = x
+		if i > 0 {
+			views[i].parent = &views[i-1]
+		}
 	}
+	return &views[len(views)-1]
 }
 
-func treeMinimum[T cmp.Ordered](x *BSTNode[T]) *BSTNode[T] {
-	y := x
-	for y.left != nil {
-		y = y.left
+// bstOwnPath appends to path the nodes from the root of the tree down to the
+// node viewed by n, replacing the ones the tree may not modify by copies. The
+// tree must not have been modified since n was retrieved.
+func bstOwnPath[T any](t *BST[T], n *BSTNode[T], path []*bstNode[T]) []*bstNode[T] {
+	if n.parent == nil {
+		t.root = bstOwn(t, t.root)
+		return append(path, t.root)
+	}
+	path = bstOwnPath(t, n.parent, path)
+	// the copy of the parent has the same children as the parent n was
+	// reached from.
+	p := path[len(path)-1]
+	if n.parent.n.left == n.n {
+		p.left = bstOwn(t, p.left)
+		return append(path, p.left)
 	}
-	return y
+	p.right = bstOwn(t, p.right)
+	return append(path, p.right)
 }
@@ -0,0 +1,95 @@
+package tree
+
+import "sync/atomic"
+
+// Cloning
+//
+// Nodes of BST and RBT don't link to their parents, so a node can be shared by
+// several trees. Clones share all their nodes with the tree they were cloned
+// from, and shared nodes are never modified. Instead, a tree about to modify a
+// node it shares replaces it by a copy, which in turn means replacing the
+// parent of the node, and so on up to the root. Modifications therefore only
+// copy the nodes on the paths they walk (path copying), plus the few nodes
+// they recolor or rotate, and the subtrees they don't touch stay shared.
+//
+// Every node records the owner of the tree that created it, and a tree only
+// modifies the nodes of its own owner in place. Cloning freezes the owner of
+// the tree, after which no tree modifies its nodes anymore, and both trees get
+// a new owner before their next modification. Since only freezing is shared
+// between trees, trees sharing nodes may be used from different goroutines.
+//
+// Public nodes (BSTNode, RBTNode and AugmentedNode) are views of the internal
+// nodes that remember the path they were reached by, which is how they find
+// their parents.
+
+// cowOwner identifies the nodes that a tree may modify in place.
+type cowOwner struct {
+	// frozen is set once the nodes are shared with a clone. Concurrent calls
+	// to Clone may set it while other goroutines read the tree.
+	frozen atomic.Bool
+}
+
+// cowPrepare must be called before modifying a tree whose owner is *o. It gives
+// the tree a new owner if its nodes are shared.
+func cowPrepare(o **cowOwner) {
+	if *o == nil || (*o).frozen.Load() {
+		*o = &cowOwner{}
+	}
+}
+
+// cowFreeze marks the nodes owned by o as shared.
+func cowFreeze(o *cowOwner) {
+	if o != nil && !o.frozen.Load() {
+		o.frozen.Store(true)
+	}
+}
+
+// Clone returns an independent copy of the tree in O(1) time. Both trees share
+// their nodes, and every later modification of either tree only copies the
+// shared nodes on the path it walks, which takes O(h) time and memory, where h
+// is the height of the tree. Nodes retrieved before a modification don't
+// reflect it, so they should be retrieved again.
+func (t *BST[T]) Clone() *BST[T] {
+	panicIfNilTree(t)
+
+	cowFreeze(t.owner)
+	c := *t
+	c.owner = nil
+	return &c
+}
+
+// Clone returns an independent copy of the tree in O(1) time. Both trees share
+// their nodes, and every later modification of either tree only copies the
+// shared nodes on the path it walks and the few nodes it rebalances, which
+// takes O(lg n) time and memory. Nodes retrieved before a modification don't
+// reflect it, so they should be retrieved again.
+func (t *RBT[T]) Clone() *RBT[T] {
+	panicIfNilTree(t)
+
+	cowFreeze(t.owner)
+	c := *t
+	c.owner = nil
+	return &c
+}
+
+// bstOwn returns x if the tree may modify it, or a copy of x that it may modify
+// otherwise, which the caller must link in place of x.
+func bstOwn[T any](t *BST[T], x *bstNode[T]) *bstNode[T] {
+	if x.owner == t.owner {
+		return x
+	}
+	c := *x
+	c.owner = t.owner
+	return &c
+}
+
+// rbtOwn returns x if the tree may modify it, or a copy of x that it may modify
+// otherwise, which the caller must link in place of x.
+func rbtOwn[T any](t *RBT[T], x *rbtNode[T]) *rbtNode[T] {
+	if x.owner == t.owner {
+		return x
+	}
+	c := *x
+	c.owner = t.owner
+	return &c
+}
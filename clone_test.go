@@ -0,0 +1,245 @@
+package tree
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	r := rand.New(rand.NewSource(71))
+
+	bst, rbt := NewBST[int](), NewRBT[int]()
+	for _, v := range r.Perm(200) {
+		bst.Insert(v)
+		rbt.Insert(v)
+	}
+	values := slices.Collect(All(rbt))
+
+	trees := map[string][2]Tree[int]{
+		"bst": {bst, bst.Clone()},
+		"rbt": {rbt, rbt.Clone()},
+	}
+	for name, trees := range trees {
+		orig, clone := trees[0], trees[1]
+		if !sameNode(orig.Root(), clone.Root()) {
+			t.Fatalf("%s: expected clone to share nodes until modified", name)
+		}
+
+		// modify the clone first, then the original.
+		clone.Delete(10)
+		clone.Insert(500)
+		if got := slices.Collect(All(orig)); !slices.Equal(got, values) {
+			t.Fatalf("%s: modifying the clone changed the original", name)
+		}
+		orig.Insert(600)
+		if clone.Count(600) != 0 || orig.Count(500) != 0 || orig.Count(10) != 1 {
+			t.Fatalf("%s: modifications are visible in the other tree", name)
+		}
+		checkParentLinks(t, orig.Root())
+		checkParentLinks(t, clone.Root())
+		if orig.Size() != 201 || clone.Size() != 200 {
+			t.Fatalf("%s: unexpected sizes %d and %d", name, orig.Size(), clone.Size())
+		}
+	}
+	checkRBTree(t, rbt)
+	checkRBTree(t, trees["rbt"][1].(*RBT[int]))
+}
+
+// rbtNodeSet returns the nodes of the subtree rooted at x.
+func rbtNodeSet(x *rbtNode[int], set map[*rbtNode[int]]bool) map[*rbtNode[int]]bool {
+	if x != nil {
+		set[x] = true
+		rbtNodeSet(x.left, set)
+		rbtNodeSet(x.right, set)
+	}
+	return set
+}
+
+func TestCloneCopiesPaths(t *testing.T) {
+	r := rand.New(rand.NewSource(79))
+
+	tr := NewRBT[int]()
+	for _, v := range r.Perm(1024) {
+		tr.Insert(2 * v)
+	}
+	orig := rbtNodeSet(tr.root, map[*rbtNode[int]]bool{})
+
+	// every modification copies the nodes it walks and rebalances, which is
+	// a small multiple of the height of the tree.
+	c := tr.Clone()
+	for range 20 {
+		v := r.Intn(2048)
+		c.Delete(v)
+		c.Insert(v | 1)
+	}
+	checkRBTree(t, c)
+	copied := 0
+	for x := range rbtNodeSet(c.root, map[*rbtNode[int]]bool{}) {
+		if !orig[x] {
+			copied++
+		}
+	}
+	if copied == 0 || copied > 20*2*3*11 {
+		t.Errorf("expected modifications to copy their paths, copied %d nodes", copied)
+	}
+	if len(rbtNodeSet(tr.root, map[*rbtNode[int]]bool{})) != 1024 || tr.Size() != 1024 {
+		t.Errorf("expected the original tree to keep its nodes")
+	}
+	checkRBTree(t, tr)
+
+	// nodes copied by a tree are modified in place afterwards, so only the
+	// deleted node disappears.
+	u := tr.Clone()
+	u.Delete(0)
+	copies := rbtNodeSet(u.root, map[*rbtNode[int]]bool{})
+	u.Delete(2)
+	after := rbtNodeSet(u.root, map[*rbtNode[int]]bool{})
+	for x := range copies {
+		if x.owner == u.owner && x.value != 2 && !after[x] {
+			t.Errorf("expected the clone not to copy its own node %d again", x.value)
+		}
+	}
+
+	b := NewBST[int]()
+	for _, v := range []int{8, 4, 12, 2, 6, 10, 14} {
+		b.Insert(v)
+	}
+	bc := b.Clone()
+	bc.Insert(7)
+	// the path to 7 was copied, while the other subtrees are still shared.
+	if bc.root == b.root || bc.root.left == b.root.left || bc.root.left.right == b.root.left.right {
+		t.Errorf("expected the path to the new node to be copied")
+	}
+	if bc.root.right != b.root.right || bc.root.left.left != b.root.left.left {
+		t.Errorf("expected the untouched subtrees to be shared")
+	}
+}
+
+func TestCloneChain(t *testing.T) {
+	tr := NewMultiRBT[int]()
+	for v := range 50 {
+		tr.Insert(v % 10)
+	}
+	c1 := tr.Clone()
+	c2 := c1.Clone()
+
+	c1.Insert(100)
+	tr.Delete(3)
+	c2.Insert(200)
+
+	for _, tc := range []struct {
+		tree     *RBT[int]
+		size     int
+		distinct int
+		count    int
+	}{
+		{tr, 49, 10, 4},
+		{c1, 51, 11, 5},
+		{c2, 51, 11, 5},
+	} {
+		checkRBTree(t, tc.tree)
+		if tc.tree.Size() != tc.size || tc.tree.Distinct() != tc.distinct || tc.tree.Count(3) != tc.count {
+			t.Errorf("expected size %d, %d distinct values and 3 stored %d times, got %d, %d and %d",
+				tc.size, tc.distinct, tc.count, tc.tree.Size(), tc.tree.Distinct(), tc.tree.Count(3))
+		}
+	}
+}
+
+func TestCloneEmpty(t *testing.T) {
+	tr := NewRBT[int]()
+	c := tr.Clone()
+	c.Insert(1)
+	tr.Insert(2)
+	if !slices.Equal(slices.Collect(All(tr)), []int{2}) || !slices.Equal(slices.Collect(All(c)), []int{1}) {
+		t.Errorf("expected empty clones to be independent")
+	}
+
+	b := NewBST[int]()
+	bc := b.Clone()
+	bc.Insert(1)
+	if b.Size() != 0 || b.Root() != nil {
+		t.Errorf("expected empty clones to be independent")
+	}
+}
+
+func TestCloneConcurrently(t *testing.T) {
+	tr := NewBST[int]()
+	for _, v := range rand.New(rand.NewSource(73)).Perm(100) {
+		tr.Insert(v)
+	}
+
+	// cloning only reads the tree, so it may happen concurrently.
+	clones := make([]*BST[int], 4)
+	var wg sync.WaitGroup
+	for i := range clones {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clones[i] = tr.Clone()
+			clones[i].Delete(i)
+		}()
+	}
+	wg.Wait()
+
+	for i, c := range clones {
+		if c.Size() != 99 || c.Count(i) != 0 {
+			t.Errorf("expected clone %d not to store %d", i, i)
+		}
+	}
+	if tr.Size() != 100 {
+		t.Errorf("expected the original tree to be unchanged")
+	}
+}
+
+func TestCloneRandomModifications(t *testing.T) {
+	r := rand.New(rand.NewSource(83))
+
+	trees := []*RBT[int]{NewMultiRBT[int]()}
+	bsts := []*BST[int]{NewMultiBST[int]()}
+	expected := [][]int{{}}
+	for range 3000 {
+		i := r.Intn(len(trees))
+		v := r.Intn(100)
+		switch op := r.Intn(10); {
+		case op == 0 && len(trees) < 8:
+			trees = append(trees, trees[i].Clone())
+			bsts = append(bsts, bsts[i].Clone())
+			expected = append(expected, slices.Clone(expected[i]))
+		case op < 4:
+			// delete through a cursor, which finds its path again.
+			if c := Seek[int](trees[i], v); c.Valid() {
+				v = c.Value()
+				c.Delete()
+				j, _ := slices.BinarySearch(expected[i], v)
+				expected[i] = slices.Delete(expected[i], j, j+1)
+				if c.Valid() && c.Value() != expected[i][j] {
+					t.Fatalf("expected cursor to move to %d, got %d", expected[i][j], c.Value())
+				}
+				Seek[int](bsts[i], v).Delete()
+			}
+		case op < 6:
+			if trees[i].Delete(v) == nil {
+				j, _ := slices.BinarySearch(expected[i], v)
+				expected[i] = slices.Delete(expected[i], j, j+1)
+				bsts[i].Delete(v)
+			}
+		default:
+			trees[i].Insert(v)
+			bsts[i].Insert(v)
+			j, _ := slices.BinarySearch(expected[i], v)
+			expected[i] = slices.Insert(expected[i], j, v)
+		}
+
+		for j, tr := range trees {
+			checkRBTree(t, tr)
+			if got := slices.Collect(All(tr)); !slices.Equal(got, expected[j]) {
+				t.Fatalf("rbt %d: expected %v, got %v", j, expected[j], got)
+			}
+			if got := slices.Collect(All(bsts[j])); !slices.Equal(got, expected[j]) {
+				t.Fatalf("bst %d: expected %v, got %v", j, expected[j], got)
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+)
+
+// Cursor points to an element of a tree, and moves through the elements of
+// the tree in either direction. Values stored multiple times are visited once
+// for every occurence, as in All.
+//
+// Unlike iterators, a cursor allows deleting the element it points to while
+// moving through the tree. Any other modification of the tree invalidates the
+// cursor, and for trees detecting modifications, such as BST and RBT, using
+// the cursor afterwards panics.
+type Cursor[T any] struct {
+	t Tree[T]
+	// n is the node storing the current element, or nil if the cursor moved
+	// past either end of the tree.
+	n Node[T]
+	// i is the index of the current occurence of the value of n.
+	i int
+	// check panics if the tree was modified by anything other than the
+	// cursor.
+	check func()
+}
+
+// nodeDeleter is implemented by trees that can delete the value stored in one
+// of their nodes without searching for it.
+type nodeDeleter[T any] interface {
+	// deleteNode removes one occurence of the value stored in n, which must
+	// belong to the tree, and returns the node storing the following element,
+	// or nil if there is none. This is a node storing the same value if other
+	// occurences remain.
+	deleteNode(n Node[T]) Node[T]
+}
+
+func (t *BST[T]) deleteNode(n Node[T]) Node[T] {
+	cowPrepare(&t.owner)
+	path := bstOwnPath(t, n.(*BSTNode[T]), nil)
+	if v := bstView(bstDeleteNode(t, path)); v != nil {
+		return v
+	}
+	return nil
+}
+
+func (t *RBT[T]) deleteNode(n Node[T]) Node[T] {
+	if v := rbtDeleteView(t, n.(*RBTNode[T])); v != nil {
+		return v
+	}
+	return nil
+}
+
+func (t *Augmented[T, A]) deleteNode(n Node[T]) Node[T] {
+	if v := rbtDeleteView(t.t, n.(*AugmentedNode[T, A]).rbt()); v != nil {
+		return (*AugmentedNode[T, A])(v)
+	}
+	return nil
+}
+
+// First returns a cursor pointing to the smallest element of the tree. The
+// cursor is not valid if the tree is empty.
+func First[T any](t Tree[T]) *Cursor[T] {
+	panicIfNilTree(t)
+
+	return &Cursor[T]{
+		t:     t,
+		n:     Min(t),
+		check: modificationCheck(t),
+	}
+}
+
+// Last returns a cursor pointing to the largest element of the tree. The
+// cursor is not valid if the tree is empty.
+func Last[T any](t Tree[T]) *Cursor[T] {
+	panicIfNilTree(t)
+
+	c := &Cursor[T]{
+		t:     t,
+		n:     Max(t),
+		check: modificationCheck(t),
+	}
+	if c.n != nil {
+		c.i = c.n.Count() - 1
+	}
+	return c
+}
+
+// Seek returns a cursor pointing to the smallest element of the tree greater
+// than or equal to value. The cursor is not valid if there is no such element.
+func Seek[T cmp.Ordered](t Tree[T], value T) *Cursor[T] {
+	return SeekFunc(t, value, cmp.Compare[T])
+}
+
+// SeekFunc is like Seek, but compares values using cmp, which must order
+// values the same way the tree does.
+func SeekFunc[T any](t Tree[T], value T, cmp func(a, b T) int) *Cursor[T] {
+	panicIfNilTree(t)
+
+	return &Cursor[T]{
+		t:     t,
+		n:     CeilingFunc(t, value, cmp),
+		check: modificationCheck(t),
+	}
+}
+
+// Valid returns whether the cursor points to an element.
+func (c *Cursor[T]) Valid() bool {
+	panicIfNilCursor(c)
+
+	return c.n != nil
+}
+
+// Value returns the element the cursor points to. It panics if the cursor is
+// not valid.
+func (c *Cursor[T]) Value() T {
+	panicIfNilCursor(c)
+	panicIfInvalidCursor(c)
+	c.check()
+
+	return c.n.Value()
+}
+
+// Next moves the cursor to the following element. The cursor becomes invalid
+// if it pointed to the largest element. It panics if the cursor is not valid.
+func (c *Cursor[T]) Next() {
+	panicIfNilCursor(c)
+	panicIfInvalidCursor(c)
+	c.check()
+
+	c.i++
+	if c.i >= c.n.Count() {
+		c.n = Successor(c.n)
+		c.i = 0
+	}
+}
+
+// Prev moves the cursor to the preceding element. The cursor becomes invalid
+// if it pointed to the smallest element. It panics if the cursor is not valid.
+func (c *Cursor[T]) Prev() {
+	panicIfNilCursor(c)
+	panicIfInvalidCursor(c)
+	c.check()
+
+	c.i--
+	if c.i < 0 {
+		c.n = Predecessor(c.n)
+		if c.n != nil {
+			c.i = c.n.Count() - 1
+		}
+	}
+}
+
+// Delete removes the element the cursor points to from the tree, and moves
+// the cursor to the following element. It returns an error if the cursor is
+// not valid.
+//
+// BST, RBT and Augmented trees delete the node the cursor points to directly,
+// while other trees search for its value again.
+func (c *Cursor[T]) Delete() error {
+	panicIfNilCursor(c)
+	if c.n == nil {
+		return errors.New("invalid cursor")
+	}
+	c.check()
+
+	if d, ok := c.t.(nodeDeleter[T]); ok {
+		removed := c.n.Count() == 1
+		c.n = d.deleteNode(c.n)
+		if removed {
+			c.i = 0
+		}
+	} else {
+		// nodes keep storing the same value when other nodes are deleted, so
+		// the successor can be found before deleting.
+		n, i := c.n, c.i
+		if n.Count() == 1 {
+			n, i = Successor(n), 0
+		}
+		if err := c.t.Delete(c.n.Value()); err != nil {
+			return err
+		}
+		c.n, c.i = n, i
+	}
+	if c.n != nil && c.i >= c.n.Count() {
+		c.n, c.i = Successor(c.n), 0
+	}
+	c.check = modificationCheck(c.t)
+	return nil
+}
+
+func panicIfNilCursor[T any](c *Cursor[T]) {
+	if c == nil {
+		panic("nil cursor")
+	}
+}
+
+func panicIfInvalidCursor[T any](c *Cursor[T]) {
+	if c.n == nil {
+		panic("invalid cursor")
+	}
+}
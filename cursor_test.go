@@ -0,0 +1,246 @@
+package tree
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCursorDelete(t *testing.T) {
+	trees := implementations()
+	trees["multirbt"] = NewMultiRBT[int]()
+	trees["multibst"] = NewMultiBST[int]()
+
+	for name, tr := range trees {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if First(tr).Valid() {
+				t.Fatalf("expected cursor of empty tree to be invalid")
+			}
+			for v := range 200 {
+				tr.Insert((v * 7) % 200)
+				tr.Insert(v % 10)
+			}
+
+			before := slices.Collect(All(tr))
+			expected := []int{}
+			for _, v := range before {
+				if v%3 != 0 {
+					expected = append(expected, v)
+				}
+			}
+
+			// delete all multiples of 3 while walking the tree.
+			visited := []int{}
+			for c := First(tr); c.Valid(); {
+				v := c.Value()
+				visited = append(visited, v)
+				if v%3 == 0 {
+					if err := c.Delete(); err != nil {
+						t.Fatalf("expected %d to be deleted, got error %s", v, err.Error())
+					}
+				} else {
+					c.Next()
+				}
+			}
+
+			if all := slices.Collect(All(tr)); !slices.Equal(all, expected) {
+				t.Fatalf("expected %v after deletions, got %v", expected, all)
+			}
+			if !slices.Equal(visited, before) {
+				t.Fatalf("expected every element to be visited in order, got %v", visited)
+			}
+			checkParentLinks(t, tr.Root())
+		})
+	}
+}
+
+func TestCursorMultiset(t *testing.T) {
+	tr := NewMultiRBT[int]()
+	for _, v := range []int{1, 2, 2, 2, 3} {
+		tr.Insert(v)
+	}
+
+	c := First[int](tr)
+	c.Next()
+	c.Next()
+	// the cursor points to the second occurence of 2.
+	if err := c.Delete(); err != nil || c.Value() != 2 {
+		t.Fatalf("expected cursor to move to the last occurence of 2")
+	}
+	if err := c.Delete(); err != nil || c.Value() != 3 {
+		t.Fatalf("expected cursor to move to 3")
+	}
+	if err := c.Delete(); err != nil || c.Valid() {
+		t.Fatalf("expected cursor to become invalid after deleting the largest element")
+	}
+	if err := c.Delete(); err == nil {
+		t.Fatalf("expected deleting through an invalid cursor to fail")
+	}
+	if got := slices.Collect(All[int](tr)); !slices.Equal(got, []int{1, 2}) {
+		t.Fatalf("expected [1 2], got %v", got)
+	}
+}
+
+func TestCursorDetectsModifications(t *testing.T) {
+	tr := NewRBT[int]()
+	for v := range 10 {
+		tr.Insert(v)
+	}
+
+	c := First[int](tr)
+	c.Next()
+	c.Delete()
+	tr.Insert(20)
+	if !expectPanic(func() { c.Next() }) {
+		t.Errorf("expected cursor to panic after the tree was modified")
+	}
+	if !expectPanic(func() { First[int](NewBST[int]()).Value() }) {
+		t.Errorf("expected invalid cursor to panic")
+	}
+}
+
+func TestCursorSeekAndPrev(t *testing.T) {
+	for name, tr := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if Last(tr).Valid() || Seek(tr, 0).Valid() {
+				t.Fatalf("expected cursors of empty tree to be invalid")
+			}
+			for v := range 50 {
+				tr.Insert(v * 2)
+			}
+
+			if c := Seek(tr, 31); c.Value() != 32 {
+				t.Fatalf("expected seek to 31 to point to 32, got %d", c.Value())
+			}
+			if c := Seek(tr, 32); c.Value() != 32 {
+				t.Fatalf("expected seek to 32 to point to 32, got %d", c.Value())
+			}
+			if Seek(tr, 99).Valid() {
+				t.Fatalf("expected seek past the largest value to be invalid")
+			}
+
+			backward := []int{}
+			for c := Last(tr); c.Valid(); c.Prev() {
+				backward = append(backward, c.Value())
+			}
+			if expected := slices.Collect(Backward(tr)); !slices.Equal(backward, expected) {
+				t.Fatalf("expected %v walking backward, got %v", expected, backward)
+			}
+
+			c := Seek(tr, 10)
+			c.Prev()
+			c.Next()
+			if c.Value() != 10 {
+				t.Fatalf("expected prev and next to return to 10, got %d", c.Value())
+			}
+			if !expectPanic(func() { Seek(tr, 99).Prev() }) {
+				t.Errorf("expected moving an invalid cursor to panic")
+			}
+		})
+	}
+}
+
+func TestCursorPrevMultiset(t *testing.T) {
+	tr := NewMultiBST[int]()
+	for _, v := range []int{1, 2, 2, 2, 3} {
+		tr.Insert(v)
+	}
+
+	backward := []int{}
+	for c := Last[int](tr); c.Valid(); c.Prev() {
+		backward = append(backward, c.Value())
+	}
+	if !slices.Equal(backward, []int{3, 2, 2, 2, 1}) {
+		t.Fatalf("expected [3 2 2 2 1], got %v", backward)
+	}
+
+	c := SeekFunc[int](tr, 2, func(a, b int) int { return a - b })
+	c.Next()
+	c.Prev()
+	c.Prev()
+	if c.Value() != 1 {
+		t.Fatalf("expected cursor to move to 1, got %d", c.Value())
+	}
+}
+
+func TestCursorDeleteNode(t *testing.T) {
+	rbt := NewRBT[int]()
+	aug := NewAugmented(SumAggregator[int]())
+	for v := range 100 {
+		rbt.Insert(v)
+		aug.Insert(v)
+	}
+
+	for c := Seek[int](rbt, 20); c.Valid() && c.Value() < 80; {
+		c.Delete()
+	}
+	checkRBTree(t, rbt)
+	if rbt.Size() != 40 || rbt.Count(50) != 0 {
+		t.Fatalf("expected [20, 80) to be deleted, got %v", slices.Collect(All[int](rbt)))
+	}
+
+	for c := Last[int](aug); c.Valid() && c.Value() >= 50; c = Last[int](aug) {
+		c.Delete()
+	}
+	// 0 + 1 + ... + 49
+	if sum, _ := aug.Aggregate(0, 0, RangeOptions{Lo: Unbounded, Hi: Unbounded}); sum != 1225 {
+		t.Fatalf("expected aggregate 1225 after deletions, got %d", sum)
+	}
+}
+
+func TestCursorDeleteAfterClone(t *testing.T) {
+	bst := NewBST[int]()
+	rbt := NewRBT[int]()
+	for _, v := range []int{5, 2, 8, 1, 3, 7, 9} {
+		bst.Insert(v)
+		rbt.Insert(v)
+	}
+	trees := map[string]struct {
+		tr    Tree[int]
+		clone func() Tree[int]
+	}{
+		"bst": {bst, func() Tree[int] { return bst.Clone() }},
+		"rbt": {rbt, func() Tree[int] { return rbt.Clone() }},
+	}
+
+	for name, tt := range trees {
+		t.Run(name, func(t *testing.T) {
+			// the first deletion copies the nodes shared with the clone, and
+			// the cursor has to follow its node into the copy.
+			c := Seek(tt.tr, 3)
+			clone := tt.clone()
+			if err := c.Delete(); err != nil || c.Value() != 5 {
+				t.Fatalf("expected cursor to move to 5 after deleting 3")
+			}
+			c.Delete()
+			if got := slices.Collect(All(tt.tr)); !slices.Equal(got, []int{1, 2, 7, 8, 9}) {
+				t.Fatalf("expected [1 2 7 8 9], got %v", got)
+			}
+			if got := slices.Collect(All(clone)); !slices.Equal(got, []int{1, 2, 3, 5, 7, 8, 9}) {
+				t.Fatalf("expected clone to be unchanged, got %v", got)
+			}
+			checkParentLinks(t, tt.tr.Root())
+		})
+	}
+}
+
+func TestCursorDeleteWithoutClones(t *testing.T) {
+	tr := NewRBT[int]()
+	for v := range 1000 {
+		tr.Insert(v)
+	}
+
+	// deleting allocates the path to the node, the views of the following
+	// node and the modification check of the cursor, whatever the size of the
+	// tree.
+	c := Seek[int](tr, 500)
+	if allocs := testing.AllocsPerRun(100, func() { c.Delete() }); allocs > 3 {
+		t.Errorf("expected at most 3 allocations per deletion, got %.1f", allocs)
+	}
+	if c.Value() != 601 || tr.Size() != 899 {
+		t.Errorf("expected 101 values to be deleted, got cursor at %d and size %d", c.Value(), tr.Size())
+	}
+}
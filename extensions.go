@@ -2,7 +2,7 @@ package tree
 
 import "cmp"
 
-func equalSubtree[T cmp.Ordered](n1, n2 Node[T]) bool {
+func equalSubtree[T any](n1, n2 Node[T], eq func(a, b T) bool) bool {
 	if n1 == nil && n2 == nil {
 		return true
 	}
@@ -11,12 +11,18 @@ func equalSubtree[T cmp.Ordered](n1, n2 Node[T]) bool {
 		return false
 	}
 
-	return n1.Value() == n2.Value() && equalSubtree(n1.Left(), n2.Left()) && equalSubtree(n1.Right(), n2.Right())
+	return eq(n1.Value(), n2.Value()) && equalSubtree(n1.Left(), n2.Left(), eq) && equalSubtree(n1.Right(), n2.Right(), eq)
 }
 
 // Equal returns whether two trees have identical shape and store the same set
-// of values.
-func Equal[T cmp.Ordered](t1, t2 Tree[T]) bool {
+// of values. Use EqualValues to compare trees by their values alone.
+func Equal[T comparable](t1, t2 Tree[T]) bool {
+	return EqualFunc(t1, t2, func(a, b T) bool { return a == b })
+}
+
+// EqualFunc is like Equal, but compares values using eq. This allows comparing
+// trees storing values that are not comparable with ==.
+func EqualFunc[T any](t1, t2 Tree[T], eq func(a, b T) bool) bool {
 	if t1 == nil && t2 == nil {
 		return true
 	}
@@ -29,5 +35,194 @@ func Equal[T cmp.Ordered](t1, t2 Tree[T]) bool {
 		return true
 	}
 
-	return equalSubtree(t1.Root(), t2.Root())
+	return equalSubtree(t1.Root(), t2.Root(), eq)
+}
+
+// Floor returns the node storing the largest value smaller than or equal to
+// value, or nil if there is no such node.
+func Floor[T cmp.Ordered](t Tree[T], value T) Node[T] {
+	return FloorFunc(t, value, cmp.Compare[T])
+}
+
+// Ceiling returns the node storing the smallest value greater than or equal to
+// value, or nil if there is no such node.
+func Ceiling[T cmp.Ordered](t Tree[T], value T) Node[T] {
+	return CeilingFunc(t, value, cmp.Compare[T])
+}
+
+// Lower returns the node storing the largest value strictly smaller than
+// value, or nil if there is no such node.
+func Lower[T cmp.Ordered](t Tree[T], value T) Node[T] {
+	return LowerFunc(t, value, cmp.Compare[T])
+}
+
+// Higher returns the node storing the smallest value strictly greater than
+// value, or nil if there is no such node.
+func Higher[T cmp.Ordered](t Tree[T], value T) Node[T] {
+	return HigherFunc(t, value, cmp.Compare[T])
+}
+
+// FloorFunc is like Floor, but compares values using cmp, which must order
+// values the same way the tree does.
+func FloorFunc[T any](t Tree[T], value T, cmp func(a, b T) int) Node[T] {
+	return floorNode(t, value, cmp, false)
+}
+
+// CeilingFunc is like Ceiling, but compares values using cmp, which must order
+// values the same way the tree does.
+func CeilingFunc[T any](t Tree[T], value T, cmp func(a, b T) int) Node[T] {
+	return ceilingNode(t, value, cmp, false)
+}
+
+// LowerFunc is like Lower, but compares values using cmp, which must order
+// values the same way the tree does.
+func LowerFunc[T any](t Tree[T], value T, cmp func(a, b T) int) Node[T] {
+	return floorNode(t, value, cmp, true)
+}
+
+// HigherFunc is like Higher, but compares values using cmp, which must order
+// values the same way the tree does.
+func HigherFunc[T any](t Tree[T], value T, cmp func(a, b T) int) Node[T] {
+	return ceilingNode(t, value, cmp, true)
+}
+
+// floorNode returns the node storing the largest value smaller than value,
+// also accepting value itself unless strict is set.
+func floorNode[T any](t Tree[T], value T, cmp func(a, b T) int, strict bool) Node[T] {
+	panicIfNilTree(t)
+
+	var best Node[T]
+	c := t.Root()
+	for c != nil {
+		o := cmp(c.Value(), value)
+		if o == 0 && !strict {
+			return c
+		}
+		if o < 0 {
+			// c is a candidate, but there may be larger ones to the right.
+			best = c
+			c = c.Right()
+		} else {
+			c = c.Left()
+		}
+	}
+	return best
+}
+
+// ceilingNode returns the node storing the smallest value greater than value,
+// also accepting value itself unless strict is set.
+func ceilingNode[T any](t Tree[T], value T, cmp func(a, b T) int, strict bool) Node[T] {
+	panicIfNilTree(t)
+
+	var best Node[T]
+	c := t.Root()
+	for c != nil {
+		o := cmp(c.Value(), value)
+		if o == 0 && !strict {
+			return c
+		}
+		if o > 0 {
+			// c is a candidate, but there may be smaller ones to the left.
+			best = c
+			c = c.Left()
+		} else {
+			c = c.Right()
+		}
+	}
+	return best
+}
+
+// Min returns the node storing the smallest value of the tree, or nil if the
+// tree is empty.
+func Min[T any](t Tree[T]) Node[T] {
+	panicIfNilTree(t)
+
+	n := t.Root()
+	if n == nil {
+		return nil
+	}
+	return subtreeMin(n)
+}
+
+// Max returns the node storing the largest value of the tree, or nil if the
+// tree is empty.
+func Max[T any](t Tree[T]) Node[T] {
+	panicIfNilTree(t)
+
+	n := t.Root()
+	if n == nil {
+		return nil
+	}
+	return subtreeMax(n)
+}
+
+// Successor returns the node following n in order, or nil if n stores the
+// largest value of its tree.
+//
+// Successor uses Parent() links instead of searching from the root, so
+// stepping through the whole tree takes O(1) amortized time per node.
+func Successor[T any](n Node[T]) Node[T] {
+	panicIfNilNode(n)
+
+	if r := n.Right(); r != nil {
+		return subtreeMin(r)
+	}
+	// go up until arriving from a left subtree.
+	p := n.Parent()
+	for p != nil && sameNode(n, p.Right()) {
+		n = p
+		p = p.Parent()
+	}
+	return p
+}
+
+// Predecessor returns the node preceding n in order, or nil if n stores the
+// smallest value of its tree.
+//
+// Predecessor uses Parent() links instead of searching from the root, so
+// stepping through the whole tree takes O(1) amortized time per node.
+func Predecessor[T any](n Node[T]) Node[T] {
+	panicIfNilNode(n)
+
+	if l := n.Left(); l != nil {
+		return subtreeMax(l)
+	}
+	// go up until arriving from a right subtree.
+	p := n.Parent()
+	for p != nil && sameNode(n, p.Left()) {
+		n = p
+		p = p.Parent()
+	}
+	return p
+}
+
+// viewer is implemented by nodes that are views of internal nodes, such as
+// BSTNode, so that retrieving the same node twice results in two different
+// values.
+type viewer interface {
+	// viewed returns the internal node.
+	viewed() any
+}
+
+// sameNode returns whether n1 and n2 are the same node of a tree.
+func sameNode[T any](n1, n2 Node[T]) bool {
+	if v1, ok := n1.(viewer); ok {
+		v2, ok := n2.(viewer)
+		return ok && v1.viewed() == v2.viewed()
+	}
+	return n1 == n2
+}
+
+func subtreeMin[T any](n Node[T]) Node[T] {
+	for l := n.Left(); l != nil; l = n.Left() {
+		n = l
+	}
+	return n
+}
+
+func subtreeMax[T any](n Node[T]) Node[T] {
+	for r := n.Right(); r != nil; r = n.Right() {
+		n = r
+	}
+	return n
 }
@@ -2,6 +2,8 @@ package tree
 
 import (
 	"cmp"
+	"math/rand"
+	"slices"
 	"testing"
 )
 
@@ -36,7 +38,7 @@ func TestEqual(t *testing.T) {
 			name: "nilAndTree",
 			t1:   nil,
 			t2: &BST[int]{
-				root: &BSTNode[int]{value: 3},
+				root: &bstNode[int]{value: 3},
 				size: 1,
 			},
 			equal: false,
@@ -44,11 +46,11 @@ func TestEqual(t *testing.T) {
 		{
 			name: "equal roots",
 			t1: &BST[int]{
-				root: &BSTNode[int]{value: 3},
+				root: &bstNode[int]{value: 3},
 				size: 1,
 			},
 			t2: &BST[int]{
-				root: &BSTNode[int]{value: 3},
+				root: &bstNode[int]{value: 3},
 				size: 1,
 			},
 			equal: true,
@@ -56,13 +58,13 @@ func TestEqual(t *testing.T) {
 		{
 			name: "equal trees",
 			t1: &BST[int]{
-				root: &BSTNode[int]{value: 3, left: &BSTNode[int]{
+				root: &bstNode[int]{value: 3, left: &bstNode[int]{
 					value: 2,
 				}},
 				size: 2,
 			},
 			t2: &BST[int]{
-				root: &BSTNode[int]{value: 3, left: &BSTNode[int]{
+				root: &bstNode[int]{value: 3, left: &bstNode[int]{
 					value: 2,
 				}},
 				size: 2,
@@ -73,15 +75,15 @@ func TestEqual(t *testing.T) {
 		// enough for now
 		{
 			name:  "differentRootValue",
-			t1:    &BST[int]{root: &BSTNode[int]{value: 3}, size: 1},
-			t2:    &BST[int]{root: &BSTNode[int]{value: 4}, size: 1},
+			t1:    &BST[int]{root: &bstNode[int]{value: 3}, size: 1},
+			t2:    &BST[int]{root: &bstNode[int]{value: 4}, size: 1},
 			equal: false,
 		},
 		{
 			name: "emptyAndSingle",
 			t1:   NewBST[int](),
 			t2: &BST[int]{
-				root: &BSTNode[int]{value: 1},
+				root: &bstNode[int]{value: 1},
 				size: 1,
 			},
 			equal: false,
@@ -89,14 +91,14 @@ func TestEqual(t *testing.T) {
 		{
 			name: "sameValuesDifferentStructure",
 			t1: func() Tree[int] {
-				root := &BSTNode[int]{value: 3}
-				left := &BSTNode[int]{value: 2, parent: root}
+				root := &bstNode[int]{value: 3}
+				left := &bstNode[int]{value: 2}
 				root.left = left
 				return &BST[int]{root: root, size: 2}
 			}(),
 			t2: func() Tree[int] {
-				root := &BSTNode[int]{value: 2}
-				right := &BSTNode[int]{value: 3, parent: root}
+				root := &bstNode[int]{value: 2}
+				right := &bstNode[int]{value: 3}
 				root.right = right
 				return &BST[int]{root: root, size: 2}
 			}(),
@@ -105,17 +107,17 @@ func TestEqual(t *testing.T) {
 		{
 			name: "mirrorNotEqual",
 			t1: func() Tree[int] {
-				r := &BSTNode[int]{value: 4}
-				l1 := &BSTNode[int]{value: 3, parent: r}
-				l2 := &BSTNode[int]{value: 2, parent: l1}
+				r := &bstNode[int]{value: 4}
+				l1 := &bstNode[int]{value: 3}
+				l2 := &bstNode[int]{value: 2}
 				r.left = l1
 				l1.left = l2
 				return &BST[int]{root: r, size: 3}
 			}(),
 			t2: func() Tree[int] {
-				r := &BSTNode[int]{value: 4}
-				rr1 := &BSTNode[int]{value: 3, parent: r}
-				rr2 := &BSTNode[int]{value: 2, parent: rr1}
+				r := &bstNode[int]{value: 4}
+				rr1 := &bstNode[int]{value: 3}
+				rr2 := &bstNode[int]{value: 2}
 				r.right = rr1
 				rr1.right = rr2
 				return &BST[int]{root: r, size: 3}
@@ -125,26 +127,26 @@ func TestEqual(t *testing.T) {
 		{
 			name: "threeLevelEqual",
 			t1: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l := &BSTNode[int]{value: 3, parent: r}
-				rg := &BSTNode[int]{value: 7, parent: r}
-				ll := &BSTNode[int]{value: 2, parent: l}
-				lr := &BSTNode[int]{value: 4, parent: l}
-				rl := &BSTNode[int]{value: 6, parent: rg}
-				rr := &BSTNode[int]{value: 8, parent: rg}
+				r := &bstNode[int]{value: 5}
+				l := &bstNode[int]{value: 3}
+				rg := &bstNode[int]{value: 7}
+				ll := &bstNode[int]{value: 2}
+				lr := &bstNode[int]{value: 4}
+				rl := &bstNode[int]{value: 6}
+				rr := &bstNode[int]{value: 8}
 				r.left, r.right = l, rg
 				l.left, l.right = ll, lr
 				rg.left, rg.right = rl, rr
 				return &BST[int]{root: r, size: 7}
 			}(),
 			t2: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l := &BSTNode[int]{value: 3, parent: r}
-				rg := &BSTNode[int]{value: 7, parent: r}
-				ll := &BSTNode[int]{value: 2, parent: l}
-				lr := &BSTNode[int]{value: 4, parent: l}
-				rl := &BSTNode[int]{value: 6, parent: rg}
-				rr := &BSTNode[int]{value: 8, parent: rg}
+				r := &bstNode[int]{value: 5}
+				l := &bstNode[int]{value: 3}
+				rg := &bstNode[int]{value: 7}
+				ll := &bstNode[int]{value: 2}
+				lr := &bstNode[int]{value: 4}
+				rl := &bstNode[int]{value: 6}
+				rr := &bstNode[int]{value: 8}
 				r.left, r.right = l, rg
 				l.left, l.right = ll, lr
 				rg.left, rg.right = rl, rr
@@ -155,26 +157,26 @@ func TestEqual(t *testing.T) {
 		{
 			name: "threeLevelValueMismatch",
 			t1: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l := &BSTNode[int]{value: 3, parent: r}
-				rg := &BSTNode[int]{value: 7, parent: r}
-				ll := &BSTNode[int]{value: 2, parent: l}
-				lr := &BSTNode[int]{value: 4, parent: l}
-				rl := &BSTNode[int]{value: 6, parent: rg}
-				rr := &BSTNode[int]{value: 8, parent: rg}
+				r := &bstNode[int]{value: 5}
+				l := &bstNode[int]{value: 3}
+				rg := &bstNode[int]{value: 7}
+				ll := &bstNode[int]{value: 2}
+				lr := &bstNode[int]{value: 4}
+				rl := &bstNode[int]{value: 6}
+				rr := &bstNode[int]{value: 8}
 				r.left, r.right = l, rg
 				l.left, l.right = ll, lr
 				rg.left, rg.right = rl, rr
 				return &BST[int]{root: r, size: 7}
 			}(),
 			t2: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l := &BSTNode[int]{value: 3, parent: r}
-				rg := &BSTNode[int]{value: 7, parent: r}
-				ll := &BSTNode[int]{value: 2, parent: l}
-				lr := &BSTNode[int]{value: 4, parent: l}
-				rl := &BSTNode[int]{value: 6, parent: rg}
-				rr := &BSTNode[int]{value: 9, parent: rg} // value differs here
+				r := &bstNode[int]{value: 5}
+				l := &bstNode[int]{value: 3}
+				rg := &bstNode[int]{value: 7}
+				ll := &bstNode[int]{value: 2}
+				lr := &bstNode[int]{value: 4}
+				rl := &bstNode[int]{value: 6}
+				rr := &bstNode[int]{value: 9} // value differs here
 				r.left, r.right = l, rg
 				l.left, l.right = ll, lr
 				rg.left, rg.right = rl, rr
@@ -185,22 +187,22 @@ func TestEqual(t *testing.T) {
 		{
 			name: "fiveNodeEqual",
 			t1: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l := &BSTNode[int]{value: 3, parent: r}
-				rl := &BSTNode[int]{value: 8, parent: r}
-				ll := &BSTNode[int]{value: 2, parent: l}
-				rrl := &BSTNode[int]{value: 6, parent: rl}
+				r := &bstNode[int]{value: 5}
+				l := &bstNode[int]{value: 3}
+				rl := &bstNode[int]{value: 8}
+				ll := &bstNode[int]{value: 2}
+				rrl := &bstNode[int]{value: 6}
 				r.left, r.right = l, rl
 				l.left = ll
 				rl.left = rrl
 				return &BST[int]{root: r, size: 5}
 			}(),
 			t2: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l := &BSTNode[int]{value: 3, parent: r}
-				rl := &BSTNode[int]{value: 8, parent: r}
-				ll := &BSTNode[int]{value: 2, parent: l}
-				rrl := &BSTNode[int]{value: 6, parent: rl}
+				r := &bstNode[int]{value: 5}
+				l := &bstNode[int]{value: 3}
+				rl := &bstNode[int]{value: 8}
+				ll := &bstNode[int]{value: 2}
+				rrl := &bstNode[int]{value: 6}
 				r.left, r.right = l, rl
 				l.left = ll
 				rl.left = rrl
@@ -211,21 +213,21 @@ func TestEqual(t *testing.T) {
 		{
 			name: "fiveNodeSizeMismatch",
 			t1: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l := &BSTNode[int]{value: 3, parent: r}
-				rl := &BSTNode[int]{value: 8, parent: r}
-				ll := &BSTNode[int]{value: 2, parent: l}
-				rrl := &BSTNode[int]{value: 6, parent: rl}
+				r := &bstNode[int]{value: 5}
+				l := &bstNode[int]{value: 3}
+				rl := &bstNode[int]{value: 8}
+				ll := &bstNode[int]{value: 2}
+				rrl := &bstNode[int]{value: 6}
 				r.left, r.right = l, rl
 				l.left = ll
 				rl.left = rrl
 				return &BST[int]{root: r, size: 5}
 			}(),
 			t2: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l := &BSTNode[int]{value: 3, parent: r}
-				rl := &BSTNode[int]{value: 8, parent: r}
-				ll := &BSTNode[int]{value: 2, parent: l}
+				r := &bstNode[int]{value: 5}
+				l := &bstNode[int]{value: 3}
+				rl := &bstNode[int]{value: 8}
+				ll := &bstNode[int]{value: 2}
 				r.left, r.right = l, rl
 				l.left = ll
 				// missing rl.left
@@ -236,20 +238,20 @@ func TestEqual(t *testing.T) {
 		{
 			name: "deepLeftEqual",
 			t1: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l1 := &BSTNode[int]{value: 4, parent: r}
-				l2 := &BSTNode[int]{value: 3, parent: l1}
-				l3 := &BSTNode[int]{value: 2, parent: l2}
+				r := &bstNode[int]{value: 5}
+				l1 := &bstNode[int]{value: 4}
+				l2 := &bstNode[int]{value: 3}
+				l3 := &bstNode[int]{value: 2}
 				r.left = l1
 				l1.left = l2
 				l2.left = l3
 				return &BST[int]{root: r, size: 4}
 			}(),
 			t2: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l1 := &BSTNode[int]{value: 4, parent: r}
-				l2 := &BSTNode[int]{value: 3, parent: l1}
-				l3 := &BSTNode[int]{value: 2, parent: l2}
+				r := &bstNode[int]{value: 5}
+				l1 := &bstNode[int]{value: 4}
+				l2 := &bstNode[int]{value: 3}
+				l3 := &bstNode[int]{value: 2}
 				r.left = l1
 				l1.left = l2
 				l2.left = l3
@@ -260,20 +262,20 @@ func TestEqual(t *testing.T) {
 		{
 			name: "deepLeftRightMismatch",
 			t1: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				l1 := &BSTNode[int]{value: 4, parent: r}
-				l2 := &BSTNode[int]{value: 3, parent: l1}
-				l3 := &BSTNode[int]{value: 2, parent: l2}
+				r := &bstNode[int]{value: 5}
+				l1 := &bstNode[int]{value: 4}
+				l2 := &bstNode[int]{value: 3}
+				l3 := &bstNode[int]{value: 2}
 				r.left = l1
 				l1.left = l2
 				l2.left = l3
 				return &BST[int]{root: r, size: 4}
 			}(),
 			t2: func() Tree[int] {
-				r := &BSTNode[int]{value: 5}
-				r1 := &BSTNode[int]{value: 6, parent: r}
-				r2 := &BSTNode[int]{value: 7, parent: r1}
-				r3 := &BSTNode[int]{value: 8, parent: r2}
+				r := &bstNode[int]{value: 5}
+				r1 := &bstNode[int]{value: 6}
+				r2 := &bstNode[int]{value: 7}
+				r3 := &bstNode[int]{value: 8}
 				r.right = r1
 				r1.right = r2
 				r2.right = r3
@@ -291,3 +293,92 @@ func TestEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestNeighbors(t *testing.T) {
+	for name, tr := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// even values in [0, 20]
+			for _, v := range []int{10, 4, 16, 0, 8, 12, 20, 2, 6, 14, 18} {
+				tr.Insert(v)
+			}
+
+			type testcase struct {
+				name     string
+				query    func(Tree[int], int) Node[int]
+				value    int
+				expected int
+				found    bool
+			}
+
+			testcases := []testcase{
+				{name: "floorEqual", query: Floor[int], value: 8, expected: 8, found: true},
+				{name: "floorBetween", query: Floor[int], value: 9, expected: 8, found: true},
+				{name: "floorAbove", query: Floor[int], value: 25, expected: 20, found: true},
+				{name: "floorBelow", query: Floor[int], value: -1, found: false},
+				{name: "ceilingEqual", query: Ceiling[int], value: 8, expected: 8, found: true},
+				{name: "ceilingBetween", query: Ceiling[int], value: 9, expected: 10, found: true},
+				{name: "ceilingBelow", query: Ceiling[int], value: -1, expected: 0, found: true},
+				{name: "ceilingAbove", query: Ceiling[int], value: 21, found: false},
+				{name: "lowerEqual", query: Lower[int], value: 8, expected: 6, found: true},
+				{name: "lowerBetween", query: Lower[int], value: 9, expected: 8, found: true},
+				{name: "lowerMin", query: Lower[int], value: 0, found: false},
+				{name: "higherEqual", query: Higher[int], value: 8, expected: 10, found: true},
+				{name: "higherBetween", query: Higher[int], value: 9, expected: 10, found: true},
+				{name: "higherMax", query: Higher[int], value: 20, found: false},
+			}
+
+			for _, tc := range testcases {
+				n := tc.query(tr, tc.value)
+				if !tc.found {
+					if n != nil {
+						t.Errorf("%s: expected no node, got %d", tc.name, n.Value())
+					}
+					continue
+				}
+				if n == nil {
+					t.Errorf("%s: expected %d, got no node", tc.name, tc.expected)
+				} else if n.Value() != tc.expected {
+					t.Errorf("%s: expected %d, got %d", tc.name, tc.expected, n.Value())
+				}
+			}
+		})
+	}
+}
+
+func TestSuccessorPredecessor(t *testing.T) {
+	for name, tr := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if Min(tr) != nil || Max(tr) != nil {
+				t.Fatalf("expected no min and max for empty tree")
+			}
+
+			r := rand.New(rand.NewSource(41))
+			values := r.Perm(200)
+			for _, v := range values {
+				tr.Insert(v)
+			}
+			slices.Sort(values)
+
+			got := []int{}
+			for n := Min(tr); n != nil; n = Successor(n) {
+				got = append(got, n.Value())
+			}
+			if !slices.Equal(got, values) {
+				t.Errorf("expected successors %v, got %v", values, got)
+			}
+
+			slices.Reverse(values)
+			got = got[:0]
+			for n := Max(tr); n != nil; n = Predecessor(n) {
+				got = append(got, n.Value())
+			}
+			if !slices.Equal(got, values) {
+				t.Errorf("expected predecessors %v, got %v", values, got)
+			}
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package tree
 
 import (
-	"cmp"
 	"fmt"
 	"io"
 	"regexp"
@@ -81,7 +80,7 @@ var availableFormats = []string{FormatLinuxTree, FormatHorizontal, FormatHorizon
 
 // FormatTree will return a string representation of the tree, based on the
 // format options provided.
-func FormatTree[T cmp.Ordered](t Tree[T], formatType string) string {
+func FormatTree[T any](t Tree[T], formatType string) string {
 	if t == nil {
 		return "nil tree"
 	}
@@ -110,12 +109,12 @@ func FormatTree[T cmp.Ordered](t Tree[T], formatType string) string {
 	return ""
 }
 
-func formatLinuxTree[T cmp.Ordered](t coloredTree[T]) string {
+func formatLinuxTree[T any](t coloredTree[T]) string {
 	if isNilOrSentinel(t.Root().Left()) && isNilOrSentinel(t.Root().Right()) {
-		return fmt.Sprint(t.Root().Value())
+		return getTtyColoredValue(t.Root())
 	}
 
-	out := fmt.Sprintf("%v\n", t.Root().Value())
+	out := fmt.Sprintf("%v\n", getTtyColoredValue(t.Root()))
 	prefix := []string{}
 
 	type stkobj struct {
@@ -188,7 +187,7 @@ func formatLinuxTree[T cmp.Ordered](t coloredTree[T]) string {
 }
 
 // horizontalFomrmatter renders a horizontal ASCII representation of a binary tree.
-type horizontalFomrmatter[T cmp.Ordered] struct {
+type horizontalFomrmatter[T any] struct {
 	out io.Writer
 	// squareBranches prints branches using Unicode box‑drawing characters
 	// instead of classic / and \.
@@ -198,7 +197,7 @@ type horizontalFomrmatter[T cmp.Ordered] struct {
 	hspace int
 }
 
-func newhf[T cmp.Ordered](out io.Writer, hspace int, squarebranches bool) *horizontalFomrmatter[T] {
+func newhf[T any](out io.Writer, hspace int, squarebranches bool) *horizontalFomrmatter[T] {
 	p := &horizontalFomrmatter[T]{
 		out:            out,
 		hspace:         hspace,
@@ -224,14 +223,7 @@ func (p *horizontalFomrmatter[T]) buildTreeLines(root Node[T]) []treeLine {
 		}
 	}
 
-	rootLabel := fmt.Sprint(root.Value())
-	var color string
-	if rootc, ok := root.(coloredNode[T]); ok {
-		color = rootc.ttycolor()
-	}
-	if color == _COLOR_RED {
-		rootLabel = ttyRed + fmt.Sprint(root.Value()) + ttyColorReset
-	}
+	rootLabel := getTtyColoredValue(root)
 	leftLines := p.buildTreeLines(root.Left())
 	rightLines := p.buildTreeLines(root.Right())
 
@@ -444,17 +436,25 @@ func maxRightOffset(lines []treeLine) int {
 
 // coloredTree is an internal interface extending the tree interface
 // to allow printing colored nodes, e.g. in Red Black trees.
-type coloredTree[T cmp.Ordered] interface {
+type coloredTree[T any] interface {
 	Tree[T]
 }
 
 // coloredNode is an interface extending the node interface to allow printing
 // colored nodes, e.g. in Red Black trees.
-type coloredNode[T cmp.Ordered] interface {
+type coloredNode[T any] interface {
 	ttycolor() string
 }
 
-func getTtyColoredValue[T cmp.Ordered](n Node[T]) string {
+// leveledNode is an interface extending the node interface to allow printing
+// the level of nodes next to their value, e.g. in AA trees.
+type leveledNode[T any] interface {
+	ttylevel() int
+}
+
+// getTtyColoredValue returns the label of a node as it should be printed,
+// including its color and its level if the node has them.
+func getTtyColoredValue[T any](n Node[T]) string {
 	label := fmt.Sprint(n.Value())
 	var color string
 	if c, ok := n.(coloredNode[T]); ok {
@@ -463,15 +463,18 @@ func getTtyColoredValue[T cmp.Ordered](n Node[T]) string {
 	if color == _COLOR_RED {
 		label = ttyRed + fmt.Sprint(n.Value()) + ttyColorReset
 	}
+	if l, ok := n.(leveledNode[T]); ok {
+		label += fmt.Sprintf("[%d]", l.ttylevel())
+	}
 
 	return label
 }
 
-type nodeWithSentinel[T cmp.Ordered] interface {
+type nodeWithSentinel[T any] interface {
 	isSentinel() bool
 }
 
-func isNodeSentinel[T cmp.Ordered](n Node[T]) bool {
+func isNodeSentinel[T any](n Node[T]) bool {
 	if n == nil {
 		return false
 	}
@@ -483,7 +486,7 @@ func isNodeSentinel[T cmp.Ordered](n Node[T]) bool {
 	return false
 }
 
-func isNilOrSentinel[T cmp.Ordered](n Node[T]) bool {
+func isNilOrSentinel[T any](n Node[T]) bool {
 	if n == nil {
 		return true
 	}
@@ -1,15 +1,21 @@
 package tree
 
-import "cmp"
-
-func panicIfNilTree[T cmp.Ordered](t Tree[T]) {
+func panicIfNilTree[T any](t Tree[T]) {
 	if t == nil {
 		panic("nil tree")
 	}
 }
 
-func panicIfNilNode[T cmp.Ordered](n Node[T]) {
+func panicIfNilNode[T any](n Node[T]) {
 	if n == nil {
 		panic("nil node")
 	}
 }
+
+// panicIfUninitialized will panic if the tree was not created by one of its
+// constructors, which set the function ordering its values.
+func panicIfUninitialized[T any](cmp func(a, b T) int) {
+	if cmp == nil {
+		panic("uninitialized tree")
+	}
+}
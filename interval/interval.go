@@ -0,0 +1,178 @@
+// Package interval provides an interval tree, which stores closed intervals and
+// efficiently finds all the intervals overlapping a given one.
+//
+// The tree is the one described in CLRS 14.3: a red black tree keyed by the
+// start of the intervals, where every node also stores the largest end of the
+// intervals in its subtree. It is built on top of tree.Augmented.
+package interval
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/Ozoniuss/tree"
+)
+
+// Interval is a closed interval [Start, End].
+type Interval[T cmp.Ordered] struct {
+	Start T
+	End   T
+}
+
+// Overlaps returns whether the two intervals have at least one point in
+// common.
+func (i Interval[T]) Overlaps(other Interval[T]) bool {
+	return i.Start <= other.End && other.Start <= i.End
+}
+
+// Contains returns whether point belongs to the interval.
+func (i Interval[T]) Contains(point T) bool {
+	return i.Start <= point && point <= i.End
+}
+
+func (i Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v]", i.Start, i.End)
+}
+
+// compare orders intervals by start, breaking ties by end.
+func compare[T cmp.Ordered](a, b Interval[T]) int {
+	if c := cmp.Compare(a.Start, b.Start); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.End, b.End)
+}
+
+// Tree is an interval tree. Intervals are unique, but several intervals may
+// share the same start.
+type Tree[T cmp.Ordered] struct {
+	t *tree.Augmented[Interval[T], T]
+}
+
+// New returns an initialized interval tree.
+func New[T cmp.Ordered]() *Tree[T] {
+	// every node aggregates the largest end of the intervals in its subtree.
+	return &Tree[T]{
+		t: tree.NewAugmentedFunc(compare[T], tree.Aggregator[Interval[T], T]{
+			Lift:    func(i Interval[T]) T { return i.End },
+			Combine: func(a, b T) T { return max(a, b) },
+		}),
+	}
+}
+
+// Insert adds an interval to the tree. It returns an error if the interval is
+// already present, or if its start is after its end.
+func (t *Tree[T]) Insert(i Interval[T]) error {
+	panicIfNilTree(t)
+
+	if i.Start > i.End {
+		return errors.New("invalid interval")
+	}
+	return t.t.Insert(i)
+}
+
+// Delete removes an interval from the tree. It returns an error if the
+// interval is not present.
+func (t *Tree[T]) Delete(i Interval[T]) error {
+	panicIfNilTree(t)
+
+	return t.t.Delete(i)
+}
+
+// Contains returns whether the interval is present in the tree.
+func (t *Tree[T]) Contains(i Interval[T]) bool {
+	panicIfNilTree(t)
+
+	return t.t.Count(i) != 0
+}
+
+// Len returns the number of intervals in the tree.
+func (t *Tree[T]) Len() int {
+	panicIfNilTree(t)
+
+	return t.t.Size()
+}
+
+// All returns an iterator over the intervals of the tree, ordered by start
+// and then by end.
+//
+// The tree must not be modified during iteration, otherwise iteration panics
+// when it resumes.
+func (t *Tree[T]) All() iter.Seq[Interval[T]] {
+	panicIfNilTree(t)
+
+	return tree.All[Interval[T]](t.t)
+}
+
+// Overlapping returns an iterator over the intervals of the tree that overlap
+// q, ordered by start and then by end.
+//
+// Subtrees that can't contain overlapping intervals are skipped, so finding k
+// intervals takes O(min(n, k lg n)) time.
+//
+// The tree must not be modified during iteration, otherwise iteration panics
+// when it resumes.
+func (t *Tree[T]) Overlapping(q Interval[T]) iter.Seq[Interval[T]] {
+	panicIfNilTree(t)
+
+	return func(yield func(Interval[T]) bool) {
+		// modifications are detected the same way as in All.
+		mods := t.t.Modifications()
+		// nodes whose left subtree was visited, but which weren't yielded yet.
+		stack := []tree.Node[Interval[T]]{}
+		n := t.t.Root()
+		for n != nil || len(stack) != 0 {
+			for n != nil {
+				// all intervals in this subtree end before q starts.
+				if maxEnd(n) < q.Start {
+					break
+				}
+				stack = append(stack, n)
+				n = n.Left()
+			}
+			if len(stack) == 0 {
+				return
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			// intervals are visited in order of their start, so all remaining
+			// ones start after q ends.
+			if n.Value().Start > q.End {
+				return
+			}
+			if n.Value().Overlaps(q) {
+				if !yield(n.Value()) {
+					return
+				}
+				if t.t.Modifications() != mods {
+					panic("tree modified during iteration")
+				}
+			}
+			n = n.Right()
+		}
+	}
+}
+
+// Stab returns an iterator over the intervals of the tree that contain point,
+// ordered by start and then by end.
+func (t *Tree[T]) Stab(point T) iter.Seq[Interval[T]] {
+	return t.Overlapping(Interval[T]{Start: point, End: point})
+}
+
+func (t *Tree[T]) String() string {
+	panicIfNilTree(t)
+
+	return tree.FormatTree[Interval[T]](t.t, tree.FormatHorizontal)
+}
+
+// maxEnd returns the largest end of the intervals in the subtree rooted at n.
+func maxEnd[T cmp.Ordered](n tree.Node[Interval[T]]) T {
+	return n.(*tree.AugmentedNode[Interval[T], T]).Aggregate()
+}
+
+func panicIfNilTree[T cmp.Ordered](t *Tree[T]) {
+	if t == nil {
+		panic("nil tree")
+	}
+}
@@ -0,0 +1,100 @@
+package interval
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestOverlapping(t *testing.T) {
+	r := rand.New(rand.NewSource(59))
+	tr := New[int]()
+	// present mirrors the content of the tree.
+	present := map[Interval[int]]struct{}{}
+
+	for range 2000 {
+		start := r.Intn(1000)
+		i := Interval[int]{Start: start, End: start + r.Intn(50)}
+		if r.Intn(4) == 0 {
+			err := tr.Delete(i)
+			if _, ok := present[i]; ok != (err == nil) {
+				t.Fatalf("unexpected result deleting %v: %v", i, err)
+			}
+			delete(present, i)
+		} else {
+			err := tr.Insert(i)
+			if _, ok := present[i]; ok == (err == nil) {
+				t.Fatalf("unexpected result inserting %v: %v", i, err)
+			}
+			present[i] = struct{}{}
+		}
+	}
+	if tr.Len() != len(present) {
+		t.Fatalf("expected %d intervals, got %d", len(present), tr.Len())
+	}
+
+	for range 200 {
+		start := r.Intn(1100) - 50
+		q := Interval[int]{Start: start, End: start + r.Intn(30)}
+
+		expected := []Interval[int]{}
+		for i := range present {
+			if i.Overlaps(q) {
+				expected = append(expected, i)
+			}
+		}
+		slices.SortFunc(expected, compare[int])
+
+		if got := slices.Collect(tr.Overlapping(q)); !slices.Equal(got, expected) {
+			t.Errorf("query %v: expected %v, got %v", q, expected, got)
+		}
+
+		expected = expected[:0]
+		for i := range present {
+			if i.Contains(q.Start) {
+				expected = append(expected, i)
+			}
+		}
+		slices.SortFunc(expected, compare[int])
+		if got := slices.Collect(tr.Stab(q.Start)); !slices.Equal(got, expected) {
+			t.Errorf("stab %d: expected %v, got %v", q.Start, expected, got)
+		}
+	}
+}
+
+func TestInsertInvalid(t *testing.T) {
+	tr := New[int]()
+	if err := tr.Insert(Interval[int]{Start: 5, End: 3}); err == nil {
+		t.Errorf("expected error when inserting an interval ending before its start")
+	}
+	if tr.Insert(Interval[int]{Start: 3, End: 3}) != nil || !tr.Contains(Interval[int]{Start: 3, End: 3}) {
+		t.Errorf("expected single point interval to be inserted")
+	}
+	if got := slices.Collect(tr.Stab(4)); len(got) != 0 {
+		t.Errorf("expected no intervals containing 4, got %v", got)
+	}
+}
+
+func TestIterationDetectsModifications(t *testing.T) {
+	tr := New[int]()
+	for i := range 10 {
+		tr.Insert(Interval[int]{Start: i, End: i + 5})
+	}
+
+	for name, seq := range map[string]func() iter.Seq[Interval[int]]{
+		"all":         tr.All,
+		"overlapping": func() iter.Seq[Interval[int]] { return tr.Overlapping(Interval[int]{Start: 3, End: 6}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: expected iteration to panic after the tree was modified", name)
+				}
+			}()
+			for i := range seq() {
+				tr.Delete(i)
+			}
+		}()
+	}
+}
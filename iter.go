@@ -0,0 +1,129 @@
+package tree
+
+import "iter"
+
+// All returns an iterator over the values of the tree, in ascending order.
+// Values stored multiple times are yielded once for every occurence.
+//
+// The tree must not be modified during iteration. BST and RBT detect
+// modifications, and panic when iteration resumes. Use a Cursor to delete
+// values while iterating.
+func All[T any](t Tree[T]) iter.Seq[T] {
+	panicIfNilTree(t)
+
+	return func(yield func(T) bool) {
+		check := modificationCheck(t)
+		for n := range AllNodes(t) {
+			for range n.Count() {
+				if !yield(n.Value()) {
+					return
+				}
+				check()
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over the values of the tree, in descending
+// order. Values stored multiple times are yielded once for every occurence.
+//
+// The tree must not be modified during iteration. BST and RBT detect
+// modifications, and panic when iteration resumes. Use a Cursor to delete
+// values while iterating.
+func Backward[T any](t Tree[T]) iter.Seq[T] {
+	panicIfNilTree(t)
+
+	return func(yield func(T) bool) {
+		check := modificationCheck(t)
+		for n := range BackwardNodes(t) {
+			for range n.Count() {
+				if !yield(n.Value()) {
+					return
+				}
+				check()
+			}
+		}
+	}
+}
+
+// AllNodes returns an iterator over the nodes of the tree, in ascending order
+// of their values.
+//
+// The tree must not be modified during iteration. BST and RBT detect
+// modifications, and panic when iteration resumes. Use a Cursor to delete
+// values while iterating.
+func AllNodes[T any](t Tree[T]) iter.Seq[Node[T]] {
+	panicIfNilTree(t)
+
+	return func(yield func(Node[T]) bool) {
+		check := modificationCheck(t)
+		// nodes whose left subtree was visited, but which weren't yielded yet.
+		stack := []Node[T]{}
+		n := t.Root()
+		for n != nil || len(stack) != 0 {
+			for n != nil {
+				stack = append(stack, n)
+				n = n.Left()
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n) {
+				return
+			}
+			check()
+			n = n.Right()
+		}
+	}
+}
+
+// BackwardNodes returns an iterator over the nodes of the tree, in descending
+// order of their values.
+//
+// The tree must not be modified during iteration. BST and RBT detect
+// modifications, and panic when iteration resumes. Use a Cursor to delete
+// values while iterating.
+func BackwardNodes[T any](t Tree[T]) iter.Seq[Node[T]] {
+	panicIfNilTree(t)
+
+	return func(yield func(Node[T]) bool) {
+		check := modificationCheck(t)
+		// nodes whose right subtree was visited, but which weren't yielded yet.
+		stack := []Node[T]{}
+		n := t.Root()
+		for n != nil || len(stack) != 0 {
+			for n != nil {
+				stack = append(stack, n)
+				n = n.Right()
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n) {
+				return
+			}
+			check()
+			n = n.Left()
+		}
+	}
+}
+
+// modCounter is implemented by trees that count their modifications, which
+// allows detecting modifications during iteration.
+type modCounter interface {
+	modifications() int
+}
+
+// modificationCheck returns a function that panics if t was modified since
+// modificationCheck was called. Modifications of trees that don't implement
+// modCounter are never detected.
+func modificationCheck[T any](t Tree[T]) func() {
+	mc, ok := t.(modCounter)
+	if !ok {
+		return func() {}
+	}
+	mods := mc.modifications()
+	return func() {
+		if mc.modifications() != mods {
+			panic("tree modified during iteration")
+		}
+	}
+}
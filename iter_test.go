@@ -0,0 +1,181 @@
+package tree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// implementations returns an empty tree of every available implementation.
+func implementations() map[string]Tree[int] {
+	return map[string]Tree[int]{
+		"bst":       NewBST[int](),
+		"rbt":       NewRBT[int](),
+		"avl":       NewAVL[int](),
+		"treap":     NewTreapWithSource[int](rand.NewSource(1)),
+		"splay":     NewSplayTree[int](),
+		"scapegoat": NewScapegoat[int](),
+		"llrb":      NewLLRB[int](),
+		"aa":        NewAATree[int](),
+		"wbt":       NewWBT[int](),
+	}
+}
+
+func TestAll(t *testing.T) {
+	for name, tr := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			for range All(tr) {
+				t.Fatalf("expected no values in empty tree")
+			}
+
+			r := rand.New(rand.NewSource(31))
+			values := r.Perm(200)
+			for _, v := range values {
+				tr.Insert(v)
+			}
+			slices.Sort(values)
+
+			if got := slices.Collect(All(tr)); !slices.Equal(got, values) {
+				t.Errorf("expected values %v in order, got %v", values, got)
+			}
+			nodes := []int{}
+			for n := range AllNodes(tr) {
+				nodes = append(nodes, n.Value())
+			}
+			if !slices.Equal(nodes, values) {
+				t.Errorf("expected nodes %v in order, got %v", values, nodes)
+			}
+
+			slices.Reverse(values)
+			if got := slices.Collect(Backward(tr)); !slices.Equal(got, values) {
+				t.Errorf("expected values %v in reverse order, got %v", values, got)
+			}
+			nodes = nodes[:0]
+			for n := range BackwardNodes(tr) {
+				nodes = append(nodes, n.Value())
+			}
+			if !slices.Equal(nodes, values) {
+				t.Errorf("expected nodes %v in reverse order, got %v", values, nodes)
+			}
+
+			// stopping early
+			got := []int{}
+			for v := range All(tr) {
+				if v == 10 {
+					break
+				}
+				got = append(got, v)
+			}
+			if len(got) != 10 {
+				t.Errorf("expected 10 values before stopping, got %v", got)
+			}
+		})
+	}
+}
+
+func TestAllMultiset(t *testing.T) {
+	tr := NewMultiRBT[int]()
+	for _, v := range []int{3, 1, 3, 2, 3, 1} {
+		tr.Insert(v)
+	}
+
+	if got := slices.Collect(All[int](tr)); !slices.Equal(got, []int{1, 1, 2, 3, 3, 3}) {
+		t.Errorf("expected every occurence to be yielded, got %v", got)
+	}
+	if got := slices.Collect(Backward[int](tr)); !slices.Equal(got, []int{3, 3, 3, 2, 1, 1}) {
+		t.Errorf("expected every occurence to be yielded in reverse, got %v", got)
+	}
+	nodes := 0
+	for range AllNodes[int](tr) {
+		nodes++
+	}
+	if nodes != 3 {
+		t.Errorf("expected 3 nodes, got %d", nodes)
+	}
+}
+
+// expectPanic reports whether fn panics.
+func expectPanic(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return false
+}
+
+func TestIteratorsDetectModifications(t *testing.T) {
+	trees := map[string]Tree[int]{
+		"bst":       NewBST[int](),
+		"rbt":       NewRBT[int](),
+		"multirbt":  NewMultiRBT[int](),
+		"augmented": NewAugmented(SumAggregator[int]()),
+	}
+	for name, tr := range trees {
+		for v := range 20 {
+			tr.Insert(v)
+		}
+
+		iterators := map[string]func(){
+			"all": func() {
+				for v := range All(tr) {
+					tr.Insert(100 + v)
+				}
+			},
+			"backward": func() {
+				for v := range Backward(tr) {
+					tr.Delete(v)
+				}
+			},
+			"allnodes": func() {
+				for n := range AllNodes(tr) {
+					tr.Delete(n.Value())
+				}
+			},
+			"backwardnodes": func() {
+				for n := range BackwardNodes(tr) {
+					tr.Insert(n.Value() - 100)
+				}
+			},
+			"range": func() {
+				for v := range Range(tr, 5, 15, RangeOptions{}) {
+					tr.Delete(v)
+				}
+			},
+		}
+		for iname, fn := range iterators {
+			if !expectPanic(fn) {
+				t.Errorf("%s: expected %s to panic after modifying the tree", name, iname)
+			}
+		}
+
+		// modifying the tree right before stopping is fine.
+		if expectPanic(func() {
+			for v := range All(tr) {
+				tr.Insert(v - 1000)
+				break
+			}
+		}) {
+			t.Errorf("%s: expected no panic when stopping after modifying the tree", name)
+		}
+	}
+
+	m := NewOrderedMap[int, string]()
+	m.Set(1, "a")
+	m.Set(2, "b")
+	if expectPanic(func() {
+		for k := range m.All() {
+			m.Set(k, "c")
+		}
+	}) {
+		t.Errorf("expected replacing values during iteration to be allowed")
+	}
+	if !expectPanic(func() {
+		for k := range m.Backward() {
+			m.Delete(k)
+		}
+	}) {
+		t.Errorf("expected deleting keys during iteration to panic")
+	}
+}
@@ -0,0 +1,313 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+)
+
+// LLRB is a left-leaning red black tree, as described by Sedgewick. It
+// corresponds to a 2-3 tree where red links always lean left, which allows a
+// much shorter implementation than RBT at the cost of doing more rotations.
+type LLRB[T cmp.Ordered] struct {
+	root *LLRBNode[T]
+	size int
+}
+
+// NewLLRB returns an initialized left-leaning red black tree.
+func NewLLRB[T cmp.Ordered]() *LLRB[T] {
+	return &LLRB[T]{
+		size: 0,
+		root: nil,
+	}
+}
+
+func (t *LLRB[T]) Root() Node[T] {
+	panicIfNilTree(t)
+
+	if t.root == nil {
+		return nil
+	}
+	return t.root
+}
+
+func (t *LLRB[T]) Size() int {
+	panicIfNilTree(t)
+
+	return t.size
+}
+
+func (t *LLRB[T]) Count(value T) int {
+	panicIfNilTree(t)
+
+	c := t.root
+	for c != nil {
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return c.Count()
+		}
+	}
+	return 0
+}
+
+func (t *LLRB[T]) Insert(value T) error {
+	panicIfNilTree(t)
+
+	root, err := llrbInsert(t.root, value)
+	if err != nil {
+		return err
+	}
+	t.root = root
+	t.root.parent = nil
+	t.root.red = false
+	t.size++
+	return nil
+}
+
+func (t *LLRB[T]) Delete(value T) error {
+	panicIfNilTree(t)
+
+	// the recursive deletion assumes the value is present.
+	if t.Count(value) == 0 {
+		return errors.New("value not found")
+	}
+
+	if !llrbIsRed(t.root.left) && !llrbIsRed(t.root.right) {
+		t.root.red = true
+	}
+	t.root = llrbDelete(t.root, value)
+	if t.root != nil {
+		t.root.parent = nil
+		t.root.red = false
+	}
+	t.size--
+	return nil
+}
+
+func (t *LLRB[T]) String() string {
+	panicIfNilTree(t)
+
+	return FormatTree(t, string(FormatHorizontal))
+}
+
+type LLRBNode[T cmp.Ordered] struct {
+	parent *LLRBNode[T]
+	left   *LLRBNode[T]
+	right  *LLRBNode[T]
+	value  T
+	// red is the color of the link from the parent to this node.
+	red bool
+}
+
+func (n *LLRBNode[T]) Parent() Node[T] {
+	panicIfNilNode(n)
+
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *LLRBNode[T]) Left() Node[T] {
+	panicIfNilNode(n)
+
+	if n.left == nil {
+		return nil
+	}
+	return n.left
+}
+
+func (n *LLRBNode[T]) Right() Node[T] {
+	panicIfNilNode(n)
+
+	if n.right == nil {
+		return nil
+	}
+	return n.right
+}
+
+func (n *LLRBNode[T]) Value() T {
+	panicIfNilNode(n)
+
+	return n.value
+}
+
+func (n *LLRBNode[T]) Count() int {
+	panicIfNilNode(n)
+
+	return 1
+}
+
+// ttycolor is used for colored terminal output.
+func (n *LLRBNode[T]) ttycolor() string {
+	panicIfNilNode(n)
+
+	if n.red {
+		return _COLOR_RED
+	}
+	return _COLOR_BLACK
+}
+
+// Tree helpers
+//
+// The helpers below work on subtrees and return the new root of the subtree
+// they were called on. Callers are responsible for linking that root to its
+// parent.
+
+func llrbIsRed[T cmp.Ordered](n *LLRBNode[T]) bool {
+	return n != nil && n.red
+}
+
+func llrbSetLeft[T cmp.Ordered](h *LLRBNode[T], l *LLRBNode[T]) {
+	h.left = l
+	if l != nil {
+		l.parent = h
+	}
+}
+
+func llrbSetRight[T cmp.Ordered](h *LLRBNode[T], r *LLRBNode[T]) {
+	h.right = r
+	if r != nil {
+		r.parent = h
+	}
+}
+
+func llrbInsert[T cmp.Ordered](h *LLRBNode[T], value T) (*LLRBNode[T], error) {
+	if h == nil {
+		return &LLRBNode[T]{value: value, red: true}, nil
+	}
+
+	if value < h.value {
+		l, err := llrbInsert(h.left, value)
+		if err != nil {
+			return h, err
+		}
+		llrbSetLeft(h, l)
+	} else if value > h.value {
+		r, err := llrbInsert(h.right, value)
+		if err != nil {
+			return h, err
+		}
+		llrbSetRight(h, r)
+	} else {
+		return h, errors.New("value already exists")
+	}
+
+	return llrbBalance(h), nil
+}
+
+// llrbDelete removes value from the subtree rooted at h, which must contain it.
+func llrbDelete[T cmp.Ordered](h *LLRBNode[T], value T) *LLRBNode[T] {
+	if value < h.value {
+		if !llrbIsRed(h.left) && !llrbIsRed(h.left.left) {
+			h = llrbMoveRedLeft(h)
+		}
+		llrbSetLeft(h, llrbDelete(h.left, value))
+		return llrbBalance(h)
+	}
+
+	if llrbIsRed(h.left) {
+		h = llrbRotateRight(h)
+	}
+	if value == h.value && h.right == nil {
+		return nil
+	}
+	if !llrbIsRed(h.right) && !llrbIsRed(h.right.left) {
+		h = llrbMoveRedRight(h)
+	}
+	if value == h.value {
+		// replace h with the minimum of its right subtree. Nodes are relinked
+		// rather than having their values swapped, so that nodes handed out to
+		// callers keep storing the same value.
+		m := h.right
+		for m.left != nil {
+			m = m.left
+		}
+		llrbSetRight(h, llrbDeleteMin(h.right))
+		llrbSetLeft(m, h.left)
+		llrbSetRight(m, h.right)
+		m.red = h.red
+		m.parent = h.parent
+		h = m
+	} else {
+		llrbSetRight(h, llrbDelete(h.right, value))
+	}
+	return llrbBalance(h)
+}
+
+// llrbDeleteMin unlinks the minimum node of the subtree rooted at h.
+func llrbDeleteMin[T cmp.Ordered](h *LLRBNode[T]) *LLRBNode[T] {
+	if h.left == nil {
+		return nil
+	}
+	if !llrbIsRed(h.left) && !llrbIsRed(h.left.left) {
+		h = llrbMoveRedLeft(h)
+	}
+	llrbSetLeft(h, llrbDeleteMin(h.left))
+	return llrbBalance(h)
+}
+
+func llrbRotateLeft[T cmp.Ordered](h *LLRBNode[T]) *LLRBNode[T] {
+	x := h.right
+	x.parent = h.parent
+	llrbSetRight(h, x.left)
+	llrbSetLeft(x, h)
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+func llrbRotateRight[T cmp.Ordered](h *LLRBNode[T]) *LLRBNode[T] {
+	x := h.left
+	x.parent = h.parent
+	llrbSetLeft(h, x.right)
+	llrbSetRight(x, h)
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+func llrbFlipColors[T cmp.Ordered](h *LLRBNode[T]) {
+	h.red = !h.red
+	h.left.red = !h.left.red
+	h.right.red = !h.right.red
+}
+
+// llrbMoveRedLeft makes either h.left or one of its children red, assuming h is
+// red and both h.left and h.left.left are black.
+func llrbMoveRedLeft[T cmp.Ordered](h *LLRBNode[T]) *LLRBNode[T] {
+	llrbFlipColors(h)
+	if llrbIsRed(h.right.left) {
+		llrbSetRight(h, llrbRotateRight(h.right))
+		h = llrbRotateLeft(h)
+		llrbFlipColors(h)
+	}
+	return h
+}
+
+// llrbMoveRedRight makes either h.right or one of its children red, assuming h
+// is red and both h.right and h.right.left are black.
+func llrbMoveRedRight[T cmp.Ordered](h *LLRBNode[T]) *LLRBNode[T] {
+	llrbFlipColors(h)
+	if llrbIsRed(h.left.left) {
+		h = llrbRotateRight(h)
+		llrbFlipColors(h)
+	}
+	return h
+}
+
+// llrbBalance restores the left-leaning invariants on the way up.
+func llrbBalance[T cmp.Ordered](h *LLRBNode[T]) *LLRBNode[T] {
+	if llrbIsRed(h.right) && !llrbIsRed(h.left) {
+		h = llrbRotateLeft(h)
+	}
+	if llrbIsRed(h.left) && llrbIsRed(h.left.left) {
+		h = llrbRotateRight(h)
+	}
+	if llrbIsRed(h.left) && llrbIsRed(h.right) {
+		llrbFlipColors(h)
+	}
+	return h
+}
@@ -0,0 +1,69 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkLLRB verifies the left-leaning red black invariants of the subtree
+// rooted at n and returns its black height.
+func checkLLRB(t *testing.T, n *LLRBNode[int]) int {
+	t.Helper()
+
+	if n == nil {
+		return 1
+	}
+	if llrbIsRed(n.right) {
+		t.Fatalf("node %d has a right leaning red link", n.value)
+	}
+	if n.red && llrbIsRed(n.left) {
+		t.Fatalf("node %d has two consecutive red links", n.value)
+	}
+	lb := checkLLRB(t, n.left)
+	rb := checkLLRB(t, n.right)
+	if lb != rb {
+		t.Fatalf("node %d has unequal black heights %d and %d", n.value, lb, rb)
+	}
+	if n.red {
+		return lb
+	}
+	return lb + 1
+}
+
+func TestLLRBInvariants(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	tr := NewLLRB[int]()
+
+	for _, v := range r.Perm(500) {
+		tr.Insert(v)
+		checkLLRB(t, tr.root)
+	}
+	checkParentLinks[int](t, tr.Root())
+
+	for _, v := range r.Perm(500)[:400] {
+		if err := tr.Delete(v); err != nil {
+			t.Fatalf("expected %d to be in tree, got error %s", v, err.Error())
+		}
+		checkLLRB(t, tr.root)
+		checkParentLinks[int](t, tr.Root())
+	}
+	if tr.Size() != 100 {
+		t.Errorf("expected 100 elements, got %d", tr.Size())
+	}
+	if tr.Delete(1000) == nil {
+		t.Errorf("expected error when deleting a missing value")
+	}
+}
+
+func TestLLRBColoredOutput(t *testing.T) {
+	tr := NewLLRB[int]()
+	tr.Insert(2)
+	tr.Insert(1)
+
+	// 1 hangs from a red link, so it should be printed in red.
+	out := FormatTree[int](tr, FormatLinuxTree)
+	expected := "2\n├── " + ttyRed + "1" + ttyColorReset + "\n└── *"
+	if out != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, out)
+	}
+}
@@ -0,0 +1,147 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// OrderedMap associates values to keys and keeps the keys sorted. It is backed
+// by a red black tree storing key/value pairs ordered by key, so all operations
+// have O(lg n) complexity.
+type OrderedMap[K cmp.Ordered, V any] struct {
+	t *RBT[mapEntry[K, V]]
+}
+
+// mapEntry is the value stored in the nodes of the underlying tree. Entries
+// are only ordered by key, which allows updating the value in place.
+type mapEntry[K cmp.Ordered, V any] struct {
+	key   K
+	value V
+}
+
+// NewOrderedMap returns an initialized ordered map.
+func NewOrderedMap[K cmp.Ordered, V any]() *OrderedMap[K, V] {
+	return &OrderedMap[K, V]{
+		t: NewRBTFunc(func(a, b mapEntry[K, V]) int {
+			return cmp.Compare(a.key, b.key)
+		}),
+	}
+}
+
+// Get returns the value associated with key. The boolean result reports
+// whether the key was found.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	panicIfNilMap(m)
+
+	if n := rbtFind(m.t, mapEntry[K, V]{key: key}); n != nil {
+		return n.value.value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Set associates value with key, replacing the previous value if the key is
+// already present.
+func (m *OrderedMap[K, V]) Set(key K, value V) {
+	panicIfNilMap(m)
+
+	if n, ok := rbtInsert(m.t, mapEntry[K, V]{key: key, value: value}); !ok {
+		n.value.value = value
+	}
+}
+
+// Delete removes key and its associated value from the map. It returns an
+// error if the key is not present.
+func (m *OrderedMap[K, V]) Delete(key K) error {
+	panicIfNilMap(m)
+
+	if err := m.t.Delete(mapEntry[K, V]{key: key}); err != nil {
+		return errors.New("key not found")
+	}
+	return nil
+}
+
+// Len returns the number of keys in the map.
+func (m *OrderedMap[K, V]) Len() int {
+	panicIfNilMap(m)
+
+	return m.t.Size()
+}
+
+// All returns an iterator over the key/value pairs of the map, in ascending
+// order of keys.
+//
+// Values of existing keys may be replaced during iteration, but adding or
+// deleting keys panics when iteration resumes.
+func (m *OrderedMap[K, V]) All() iter.Seq2[K, V] {
+	panicIfNilMap(m)
+
+	return func(yield func(K, V) bool) {
+		check := modificationCheck(m.t)
+		// nodes don't link to their parents, so the ones left to visit are
+		// kept on a stack.
+		stack := []*rbtNode[mapEntry[K, V]]{}
+		for n := m.t.root; n != nil || len(stack) != 0; n = n.right {
+			for ; n != nil; n = n.left {
+				stack = append(stack, n)
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.value.key, n.value.value) {
+				return
+			}
+			check()
+		}
+	}
+}
+
+// Backward returns an iterator over the key/value pairs of the map, in
+// descending order of keys.
+//
+// Values of existing keys may be replaced during iteration, but adding or
+// deleting keys panics when iteration resumes.
+func (m *OrderedMap[K, V]) Backward() iter.Seq2[K, V] {
+	panicIfNilMap(m)
+
+	return func(yield func(K, V) bool) {
+		check := modificationCheck(m.t)
+		stack := []*rbtNode[mapEntry[K, V]]{}
+		for n := m.t.root; n != nil || len(stack) != 0; n = n.left {
+			for ; n != nil; n = n.right {
+				stack = append(stack, n)
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.value.key, n.value.value) {
+				return
+			}
+			check()
+		}
+	}
+}
+
+func (m *OrderedMap[K, V]) String() string {
+	panicIfNilMap(m)
+
+	b := strings.Builder{}
+	b.WriteString("map[")
+	first := true
+	for k, v := range m.All() {
+		if !first {
+			b.WriteString(" ")
+		}
+		first = false
+		fmt.Fprintf(&b, "%v:%v", k, v)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func panicIfNilMap[K cmp.Ordered, V any](m *OrderedMap[K, V]) {
+	if m == nil {
+		panic("nil map")
+	}
+}
@@ -0,0 +1,78 @@
+package tree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	r := rand.New(rand.NewSource(29))
+	m := NewOrderedMap[int, string]()
+
+	if _, ok := m.Get(1); ok {
+		t.Errorf("expected empty map not to contain 1")
+	}
+	for k, v := range m.All() {
+		t.Errorf("expected no pairs in empty map, got %d:%s", k, v)
+	}
+
+	keys := r.Perm(100)
+	for _, k := range keys {
+		m.Set(k, "old")
+	}
+	for _, k := range keys[:50] {
+		m.Set(k, "new")
+	}
+	if m.Len() != 100 {
+		t.Fatalf("expected 100 keys, got %d", m.Len())
+	}
+	for i, k := range keys {
+		expected := "old"
+		if i < 50 {
+			expected = "new"
+		}
+		if v, ok := m.Get(k); !ok || v != expected {
+			t.Errorf("expected %d to map to %s, got %s (found: %t)", k, expected, v, ok)
+		}
+	}
+
+	for _, k := range keys[25:75] {
+		if err := m.Delete(k); err != nil {
+			t.Fatalf("expected %d to be in map, got error %s", k, err.Error())
+		}
+	}
+	if err := m.Delete(keys[25]); err == nil {
+		t.Errorf("expected error when deleting a missing key")
+	}
+
+	expected := slices.Concat(keys[:25], keys[75:])
+	slices.Sort(expected)
+	got := []int{}
+	for k := range m.All() {
+		got = append(got, k)
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected keys %v in order, got %v", expected, got)
+	}
+
+	slices.Reverse(expected)
+	got = got[:0]
+	for k := range m.Backward() {
+		got = append(got, k)
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected keys %v in reverse order, got %v", expected, got)
+	}
+}
+
+func TestOrderedMapString(t *testing.T) {
+	m := NewOrderedMap[string, int]()
+	m.Set("b", 2)
+	m.Set("a", 1)
+	m.Set("c", 3)
+
+	if s := m.String(); s != "map[a:1 b:2 c:3]" {
+		t.Errorf("unexpected string representation %s", s)
+	}
+}
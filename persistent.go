@@ -0,0 +1,343 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// PersistentRBT is an immutable red black tree. Insert and Delete leave the
+// tree unchanged and return a new version instead, which shares all subtrees
+// not affected by the operation with the previous version. Every version
+// remains valid and can be read independently of the others, while each
+// operation only allocates O(lg n) new nodes.
+//
+// Since nodes may belong to multiple versions, they don't store parent links
+// and are not exposed as Node values. Nodes are balanced as in a left-leaning
+// red black tree, see LLRB.
+//
+// The zero value is not usable, trees must be created with NewPersistentRBT or
+// NewPersistentRBTFunc. Since versions are never modified, they are safe for
+// concurrent use.
+type PersistentRBT[T any] struct {
+	root *persistentNode[T]
+	size int
+	// cmp orders the values stored in the tree.
+	cmp func(a, b T) int
+}
+
+type persistentNode[T any] struct {
+	left  *persistentNode[T]
+	right *persistentNode[T]
+	value T
+	// red is the color of the link from the parent to this node.
+	red bool
+}
+
+// NewPersistentRBT returns an empty persistent red black tree.
+func NewPersistentRBT[T cmp.Ordered]() *PersistentRBT[T] {
+	return NewPersistentRBTFunc(cmp.Compare[T])
+}
+
+// NewPersistentRBTFunc returns an empty persistent red black tree that orders
+// its values using cmp. See NewRBTFunc.
+func NewPersistentRBTFunc[T any](cmp func(a, b T) int) *PersistentRBT[T] {
+	return &PersistentRBT[T]{
+		size: 0,
+		root: nil,
+		cmp:  cmp,
+	}
+}
+
+// Size returns the number of values in this version of the tree.
+func (t *PersistentRBT[T]) Size() int {
+	panicIfNilPersistentTree(t)
+
+	return t.size
+}
+
+// Count returns 1 if value belongs to this version of the tree, and 0
+// otherwise.
+func (t *PersistentRBT[T]) Count(value T) int {
+	panicIfNilPersistentTree(t)
+
+	c := t.root
+	for c != nil {
+		if o := t.cmp(value, c.value); o < 0 {
+			c = c.left
+		} else if o > 0 {
+			c = c.right
+		} else {
+			return 1
+		}
+	}
+	return 0
+}
+
+// Insert returns a new version of the tree that also stores value. If value
+// already exists, it returns an error together with the tree itself.
+func (t *PersistentRBT[T]) Insert(value T) (*PersistentRBT[T], error) {
+	panicIfNilPersistentTree(t)
+
+	root, err := prbtInsert(t, t.root, value)
+	if err != nil {
+		return t, err
+	}
+	root.red = false
+	return &PersistentRBT[T]{
+		size: t.size + 1,
+		root: root,
+		cmp:  t.cmp,
+	}, nil
+}
+
+// Delete returns a new version of the tree that no longer stores value. If
+// value doesn't exist, it returns an error together with the tree itself.
+func (t *PersistentRBT[T]) Delete(value T) (*PersistentRBT[T], error) {
+	panicIfNilPersistentTree(t)
+
+	// the recursive deletion assumes the value is present.
+	if t.Count(value) == 0 {
+		return t, errors.New("value not found")
+	}
+
+	root := t.root
+	if !prbtIsRed(root.left) && !prbtIsRed(root.right) {
+		root = prbtClone(root)
+		root.red = true
+	}
+	root = prbtDelete(t, root, value)
+	if root != nil {
+		root.red = false
+	}
+	return &PersistentRBT[T]{
+		size: t.size - 1,
+		root: root,
+		cmp:  t.cmp,
+	}, nil
+}
+
+// All returns an iterator over the values of this version of the tree, in
+// ascending order.
+func (t *PersistentRBT[T]) All() iter.Seq[T] {
+	panicIfNilPersistentTree(t)
+
+	return func(yield func(T) bool) {
+		stack := []*persistentNode[T]{}
+		n := t.root
+		for n != nil || len(stack) != 0 {
+			for n != nil {
+				stack = append(stack, n)
+				n = n.left
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.value) {
+				return
+			}
+			n = n.right
+		}
+	}
+}
+
+// Backward returns an iterator over the values of this version of the tree, in
+// descending order.
+func (t *PersistentRBT[T]) Backward() iter.Seq[T] {
+	panicIfNilPersistentTree(t)
+
+	return func(yield func(T) bool) {
+		stack := []*persistentNode[T]{}
+		n := t.root
+		for n != nil || len(stack) != 0 {
+			for n != nil {
+				stack = append(stack, n)
+				n = n.right
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.value) {
+				return
+			}
+			n = n.left
+		}
+	}
+}
+
+func (t *PersistentRBT[T]) String() string {
+	panicIfNilPersistentTree(t)
+
+	b := strings.Builder{}
+	b.WriteString("[")
+	first := true
+	for v := range t.All() {
+		if !first {
+			b.WriteString(" ")
+		}
+		first = false
+		fmt.Fprintf(&b, "%v", v)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+func panicIfNilPersistentTree[T any](t *PersistentRBT[T]) {
+	if t == nil {
+		panic("nil tree")
+	}
+}
+
+// Tree helpers
+//
+// The helpers below follow the ones of LLRB, but never modify nodes that may
+// belong to an existing version. Nodes are cloned before being modified, and
+// every helper expects the node it is called on to be a clone already, except
+// for prbtInsert, prbtDelete and prbtDeleteMin, which clone it themselves.
+// Helpers return the new root of the subtree they were called on.
+
+func prbtIsRed[T any](n *persistentNode[T]) bool {
+	return n != nil && n.red
+}
+
+func prbtClone[T any](n *persistentNode[T]) *persistentNode[T] {
+	c := *n
+	return &c
+}
+
+func prbtInsert[T any](t *PersistentRBT[T], h *persistentNode[T], value T) (*persistentNode[T], error) {
+	if h == nil {
+		return &persistentNode[T]{value: value, red: true}, nil
+	}
+
+	o := t.cmp(value, h.value)
+	if o == 0 {
+		return h, errors.New("value already exists")
+	}
+	h = prbtClone(h)
+	if o < 0 {
+		l, err := prbtInsert(t, h.left, value)
+		if err != nil {
+			return h, err
+		}
+		h.left = l
+	} else {
+		r, err := prbtInsert(t, h.right, value)
+		if err != nil {
+			return h, err
+		}
+		h.right = r
+	}
+	return prbtBalance(h), nil
+}
+
+// prbtDelete removes value from the subtree rooted at h, which must contain it.
+func prbtDelete[T any](t *PersistentRBT[T], h *persistentNode[T], value T) *persistentNode[T] {
+	h = prbtClone(h)
+	if t.cmp(value, h.value) < 0 {
+		if !prbtIsRed(h.left) && !prbtIsRed(h.left.left) {
+			h = prbtMoveRedLeft(h)
+		}
+		h.left = prbtDelete(t, h.left, value)
+		return prbtBalance(h)
+	}
+
+	if prbtIsRed(h.left) {
+		h = prbtRotateRight(h)
+	}
+	if t.cmp(value, h.value) == 0 && h.right == nil {
+		return nil
+	}
+	if !prbtIsRed(h.right) && !prbtIsRed(h.right.left) {
+		h = prbtMoveRedRight(h)
+	}
+	if t.cmp(value, h.value) == 0 {
+		// h is a clone, so it can take the value of the minimum of its right
+		// subtree without affecting other versions.
+		m := h.right
+		for m.left != nil {
+			m = m.left
+		}
+		h.value = m.value
+		h.right = prbtDeleteMin(h.right)
+	} else {
+		h.right = prbtDelete(t, h.right, value)
+	}
+	return prbtBalance(h)
+}
+
+// prbtDeleteMin removes the minimum node of the subtree rooted at h.
+func prbtDeleteMin[T any](h *persistentNode[T]) *persistentNode[T] {
+	if h.left == nil {
+		return nil
+	}
+	h = prbtClone(h)
+	if !prbtIsRed(h.left) && !prbtIsRed(h.left.left) {
+		h = prbtMoveRedLeft(h)
+	}
+	h.left = prbtDeleteMin(h.left)
+	return prbtBalance(h)
+}
+
+func prbtRotateLeft[T any](h *persistentNode[T]) *persistentNode[T] {
+	x := prbtClone(h.right)
+	h.right = x.left
+	x.left = h
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+func prbtRotateRight[T any](h *persistentNode[T]) *persistentNode[T] {
+	x := prbtClone(h.left)
+	h.left = x.right
+	x.right = h
+	x.red = h.red
+	h.red = true
+	return x
+}
+
+func prbtFlipColors[T any](h *persistentNode[T]) {
+	h.red = !h.red
+	h.left = prbtClone(h.left)
+	h.left.red = !h.left.red
+	h.right = prbtClone(h.right)
+	h.right.red = !h.right.red
+}
+
+// prbtMoveRedLeft makes either h.left or one of its children red, assuming h
+// is red and both h.left and h.left.left are black.
+func prbtMoveRedLeft[T any](h *persistentNode[T]) *persistentNode[T] {
+	prbtFlipColors(h)
+	if prbtIsRed(h.right.left) {
+		h.right = prbtRotateRight(h.right)
+		h = prbtRotateLeft(h)
+		prbtFlipColors(h)
+	}
+	return h
+}
+
+// prbtMoveRedRight makes either h.right or one of its children red, assuming h
+// is red and both h.right and h.right.left are black.
+func prbtMoveRedRight[T any](h *persistentNode[T]) *persistentNode[T] {
+	prbtFlipColors(h)
+	if prbtIsRed(h.left.left) {
+		h = prbtRotateRight(h)
+		prbtFlipColors(h)
+	}
+	return h
+}
+
+// prbtBalance restores the left-leaning invariants on the way up.
+func prbtBalance[T any](h *persistentNode[T]) *persistentNode[T] {
+	if prbtIsRed(h.right) && !prbtIsRed(h.left) {
+		h = prbtRotateLeft(h)
+	}
+	if prbtIsRed(h.left) && prbtIsRed(h.left.left) {
+		h = prbtRotateRight(h)
+	}
+	if prbtIsRed(h.left) && prbtIsRed(h.right) {
+		prbtFlipColors(h)
+	}
+	return h
+}
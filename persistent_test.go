@@ -0,0 +1,147 @@
+package tree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// checkPersistent verifies the left-leaning red black properties of the
+// subtree rooted at n, returning its black height.
+func checkPersistent(t *testing.T, n *persistentNode[int]) int {
+	t.Helper()
+
+	if n == nil {
+		return 0
+	}
+	if prbtIsRed(n.right) {
+		t.Fatalf("node %d has a red right child", n.value)
+	}
+	if n.red && prbtIsRed(n.left) {
+		t.Fatalf("red node %d has a red left child", n.value)
+	}
+	lbh := checkPersistent(t, n.left)
+	rbh := checkPersistent(t, n.right)
+	if lbh != rbh {
+		t.Fatalf("node %d has black heights %d and %d", n.value, lbh, rbh)
+	}
+	if n.red {
+		return lbh
+	}
+	return lbh + 1
+}
+
+// collectPersistentNodes returns the set of nodes of the subtree rooted at n.
+func collectPersistentNodes(n *persistentNode[int], nodes map[*persistentNode[int]]bool) {
+	if n == nil {
+		return
+	}
+	nodes[n] = true
+	collectPersistentNodes(n.left, nodes)
+	collectPersistentNodes(n.right, nodes)
+}
+
+func TestPersistentRBTVersions(t *testing.T) {
+	r := rand.New(rand.NewSource(67))
+
+	versions := []*PersistentRBT[int]{NewPersistentRBT[int]()}
+	contents := [][]int{{}}
+	for range 2000 {
+		last := versions[len(versions)-1]
+		values := slices.Clone(contents[len(contents)-1])
+
+		v := r.Intn(300)
+		i, found := slices.BinarySearch(values, v)
+		var next *PersistentRBT[int]
+		var err error
+		if r.Intn(3) == 0 {
+			next, err = last.Delete(v)
+			if found {
+				values = slices.Delete(values, i, i+1)
+			}
+		} else {
+			next, err = last.Insert(v)
+			if !found {
+				values = slices.Insert(values, i, v)
+			}
+		}
+
+		if (err == nil) == (slices.Equal(values, contents[len(contents)-1])) {
+			t.Fatalf("unexpected error %v for value %d", err, v)
+		}
+		if err != nil && next != last {
+			t.Fatalf("expected failed operations to return the same version")
+		}
+		if next.root != nil && next.root.red {
+			t.Fatalf("red root")
+		}
+		checkPersistent(t, next.root)
+		versions = append(versions, next)
+		contents = append(contents, values)
+	}
+
+	// every version must still store the values it had when it was created.
+	for i, version := range versions {
+		if got := slices.Collect(version.All()); !slices.Equal(got, contents[i]) {
+			t.Fatalf("version %d: expected %v, got %v", i, contents[i], got)
+		}
+		if version.Size() != len(contents[i]) {
+			t.Fatalf("version %d: expected size %d, got %d", i, len(contents[i]), version.Size())
+		}
+		reversed := slices.Clone(contents[i])
+		slices.Reverse(reversed)
+		if got := slices.Collect(version.Backward()); !slices.Equal(got, reversed) {
+			t.Fatalf("version %d: expected %v in reverse order, got %v", i, reversed, got)
+		}
+		checkPersistent(t, version.root)
+	}
+}
+
+func TestPersistentRBTSharing(t *testing.T) {
+	v1 := NewPersistentRBT[int]()
+	for i := range 1 << 12 {
+		v1, _ = v1.Insert(i)
+	}
+	old := map[*persistentNode[int]]bool{}
+	collectPersistentNodes(v1.root, old)
+
+	v2, _ := v1.Insert(-1)
+	v3, _ := v2.Delete(2000)
+	for _, version := range []*PersistentRBT[int]{v2, v3} {
+		nodes := map[*persistentNode[int]]bool{}
+		collectPersistentNodes(version.root, nodes)
+		fresh := 0
+		for n := range nodes {
+			if !old[n] {
+				fresh++
+			}
+		}
+		// only the nodes around the search path are copied.
+		if fresh > 100 {
+			t.Errorf("expected a logarithmic number of new nodes, got %d", fresh)
+		}
+	}
+
+	if v1.Count(-1) != 0 || v2.Count(-1) != 1 || v2.Count(2000) != 1 || v3.Count(2000) != 0 {
+		t.Errorf("operations on new versions are visible in older ones")
+	}
+	if v1.Size() != 1<<12 || v2.Size() != 1<<12+1 || v3.Size() != 1<<12 {
+		t.Errorf("unexpected sizes %d, %d and %d", v1.Size(), v2.Size(), v3.Size())
+	}
+}
+
+func TestPersistentRBTString(t *testing.T) {
+	v := NewPersistentRBTFunc(func(a, b string) int { return len(a) - len(b) })
+	if v.String() != "[]" {
+		t.Errorf("expected empty tree to be formatted as [], got %s", v.String())
+	}
+	for _, s := range []string{"ccc", "a", "bb"} {
+		v, _ = v.Insert(s)
+	}
+	if _, err := v.Insert("dd"); err == nil {
+		t.Errorf("expected inserting an equal value to fail")
+	}
+	if v.String() != "[a bb ccc]" {
+		t.Errorf("expected [a bb ccc], got %s", v.String())
+	}
+}
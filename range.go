@@ -0,0 +1,104 @@
+package tree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Bound describes how one end of a range is treated.
+type Bound int
+
+const (
+	// Inclusive bounds include the bound value in the range.
+	Inclusive Bound = iota
+	// Exclusive bounds exclude the bound value from the range.
+	Exclusive
+	// Unbounded ends ignore the bound value, which means the range extends to
+	// the smallest or largest value of the tree.
+	Unbounded
+)
+
+// RangeOptions configures the two ends of a range. The zero value describes
+// the closed range [lo, hi].
+type RangeOptions struct {
+	Lo Bound
+	Hi Bound
+}
+
+// Range returns an iterator over the values of the tree between lo and hi, in
+// ascending order. Values stored multiple times are yielded once for every
+// occurence.
+//
+// Subtrees lying outside the range are never visited, so iterating over k
+// values of a balanced tree takes O(lg n + k) time.
+//
+// The tree must not be modified during iteration. BST and RBT detect
+// modifications, and panic when iteration resumes.
+func Range[T cmp.Ordered](t Tree[T], lo, hi T, opts RangeOptions) iter.Seq[T] {
+	return RangeFunc(t, lo, hi, opts, cmp.Compare[T])
+}
+
+// RangeFunc is like Range, but compares values using cmp, which must order
+// values the same way the tree does.
+func RangeFunc[T any](t Tree[T], lo, hi T, opts RangeOptions, cmp func(a, b T) int) iter.Seq[T] {
+	panicIfNilTree(t)
+
+	// aboveLo and belowHi report whether v is on the right side of each end of
+	// the range.
+	aboveLo := func(v T) bool {
+		switch opts.Lo {
+		case Exclusive:
+			return cmp(v, lo) > 0
+		case Unbounded:
+			return true
+		default:
+			return cmp(v, lo) >= 0
+		}
+	}
+	belowHi := func(v T) bool {
+		switch opts.Hi {
+		case Exclusive:
+			return cmp(v, hi) < 0
+		case Unbounded:
+			return true
+		default:
+			return cmp(v, hi) <= 0
+		}
+	}
+
+	return func(yield func(T) bool) {
+		check := modificationCheck(t)
+		// nodes within the lower end whose left subtree was visited, but which
+		// weren't yielded yet.
+		stack := []Node[T]{}
+		n := t.Root()
+		for n != nil || len(stack) != 0 {
+			for n != nil {
+				if aboveLo(n.Value()) {
+					stack = append(stack, n)
+					n = n.Left()
+				} else {
+					// n and its whole left subtree are below the range.
+					n = n.Right()
+				}
+			}
+			if len(stack) == 0 {
+				return
+			}
+			n = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			// values are visited in order, so all remaining ones are above
+			// the range as well.
+			if !belowHi(n.Value()) {
+				return
+			}
+			for range n.Count() {
+				if !yield(n.Value()) {
+					return
+				}
+				check()
+			}
+			n = n.Right()
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package tree
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	r := rand.New(rand.NewSource(37))
+	tr := NewRBT[int]()
+	// even values only, so that bounds fall both on and between values.
+	for _, v := range r.Perm(50) {
+		tr.Insert(2 * v)
+	}
+
+	bounds := []Bound{Inclusive, Exclusive, Unbounded}
+	for _, lo := range []int{-5, 0, 7, 20, 98, 150} {
+		for _, hi := range []int{-1, 0, 20, 21, 98, 150} {
+			for _, lob := range bounds {
+				for _, hib := range bounds {
+					opts := RangeOptions{Lo: lob, Hi: hib}
+					t.Run(fmt.Sprintf("%d_%d_%v", lo, hi, opts), func(t *testing.T) {
+						expected := []int{}
+						for v := range All[int](tr) {
+							if (lob == Inclusive && v < lo) || (lob == Exclusive && v <= lo) {
+								continue
+							}
+							if (hib == Inclusive && v > hi) || (hib == Exclusive && v >= hi) {
+								continue
+							}
+							expected = append(expected, v)
+						}
+						got := slices.Collect(Range[int](tr, lo, hi, opts))
+						if !slices.Equal(got, expected) {
+							t.Errorf("expected %v, got %v", expected, got)
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+func TestRangeFunc(t *testing.T) {
+	// reverse ordering
+	desc := func(a, b string) int {
+		if a > b {
+			return -1
+		} else if a < b {
+			return 1
+		}
+		return 0
+	}
+	tr := NewBSTFunc(desc)
+	for _, v := range []string{"a", "b", "c", "d", "e"} {
+		tr.Insert(v)
+	}
+
+	got := slices.Collect(RangeFunc[string](tr, "d", "b", RangeOptions{Hi: Exclusive}, desc))
+	if !slices.Equal(got, []string{"d", "c"}) {
+		t.Errorf("expected [d c], got %v", got)
+	}
+
+	if got := slices.Collect(RangeFunc[string](NewBSTFunc(desc), "d", "b", RangeOptions{}, desc)); len(got) != 0 {
+		t.Errorf("expected empty range for empty tree, got %v", got)
+	}
+}
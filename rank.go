@@ -0,0 +1,99 @@
+package tree
+
+import "cmp"
+
+// Rank returns the number of elements of the tree that are strictly smaller
+// than value. Elements stored multiple times are counted once for every
+// occurence.
+//
+// Rank takes O(lg n) time for balanced trees whose nodes implement SizedNode,
+// such as RBT, AVLTree and WBT. Other trees are scanned in order, which takes
+// O(n) time.
+func Rank[T cmp.Ordered](t Tree[T], value T) int {
+	return RankFunc(t, value, cmp.Compare[T])
+}
+
+// RankFunc is like Rank, but compares values using cmp, which must order values
+// the same way the tree does.
+func RankFunc[T any](t Tree[T], value T, cmp func(a, b T) int) int {
+	panicIfNilTree(t)
+
+	root := t.Root()
+	if root == nil {
+		return 0
+	}
+
+	if _, ok := root.(SizedNode[T]); !ok {
+		rank := 0
+		for n := range AllNodes(t) {
+			if cmp(n.Value(), value) >= 0 {
+				break
+			}
+			rank += n.Count()
+		}
+		return rank
+	}
+
+	rank := 0
+	c := root
+	for c != nil {
+		if cmp(value, c.Value()) <= 0 {
+			c = c.Left()
+		} else {
+			// c and its whole left subtree are smaller than value.
+			rank += subtreeSize(c.Left()) + c.Count()
+			c = c.Right()
+		}
+	}
+	return rank
+}
+
+// Select returns the node storing the k-th smallest element of the tree,
+// counting from 0, or nil if k is out of range. For values stored multiple
+// times, every occurence has its own position, so Select(t, Rank(t, v)) is the
+// node storing v if v belongs to the tree.
+//
+// Select takes O(lg n) time for balanced trees whose nodes implement
+// SizedNode, such as RBT, AVLTree and WBT. Other trees are scanned in order,
+// which takes O(n) time.
+func Select[T any](t Tree[T], k int) Node[T] {
+	panicIfNilTree(t)
+
+	root := t.Root()
+	if root == nil || k < 0 {
+		return nil
+	}
+
+	if _, ok := root.(SizedNode[T]); !ok {
+		for n := range AllNodes(t) {
+			if k < n.Count() {
+				return n
+			}
+			k -= n.Count()
+		}
+		return nil
+	}
+
+	c := root
+	for c != nil {
+		ls := subtreeSize(c.Left())
+		if k < ls {
+			c = c.Left()
+		} else if k < ls+c.Count() {
+			return c
+		} else {
+			k -= ls + c.Count()
+			c = c.Right()
+		}
+	}
+	return nil
+}
+
+// subtreeSize returns the size of the subtree rooted at n, which must be nil or
+// implement SizedNode.
+func subtreeSize[T any](n Node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.(SizedNode[T]).SubtreeSize()
+}
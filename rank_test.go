@@ -0,0 +1,90 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkRBTSizes verifies the subtree sizes stored in the red black tree nodes
+// below n, returning the size of the subtree.
+func checkRBTSizes(t *testing.T, tr *RBT[int], n *rbtNode[int]) int {
+	t.Helper()
+
+	if n == nil {
+		return 0
+	}
+	size := checkRBTSizes(t, tr, n.left) + checkRBTSizes(t, tr, n.right) + n.count
+	if n.size != size {
+		t.Fatalf("node %d stores size %d, expected %d", n.value, n.size, size)
+	}
+	return size
+}
+
+func TestRBTSizes(t *testing.T) {
+	r := rand.New(rand.NewSource(43))
+
+	for _, tr := range []*RBT[int]{NewRBT[int](), NewMultiRBT[int]()} {
+		for range 2000 {
+			v := r.Intn(300)
+			if r.Intn(3) == 0 {
+				tr.Delete(v)
+			} else {
+				tr.Insert(v)
+			}
+			checkRBTSizes(t, tr, tr.root)
+		}
+		if tr.root != nil && tr.root.size != tr.Size() {
+			t.Errorf("root size %d differs from tree size %d", tr.root.size, tr.Size())
+		}
+	}
+}
+
+func TestRankSelect(t *testing.T) {
+	trees := implementations()
+	trees["multirbt"] = NewMultiRBT[int]()
+	trees["multibst"] = NewMultiBST[int]()
+
+	for name, tr := range trees {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if Select(tr, 0) != nil || Rank(tr, 5) != 0 {
+				t.Fatalf("expected no rank and select results for empty tree")
+			}
+
+			r := rand.New(rand.NewSource(47))
+			// sorted contains every element of the tree, in order.
+			sorted := []int{}
+			for v := range 100 {
+				tr.Insert(2 * v)
+			}
+			for range 50 {
+				// inserting existing values only has an effect on multisets.
+				tr.Insert(2 * r.Intn(100))
+			}
+			for v := range All(tr) {
+				sorted = append(sorted, v)
+			}
+
+			for v := -1; v <= 200; v++ {
+				expected := 0
+				for _, s := range sorted {
+					if s < v {
+						expected++
+					}
+				}
+				if got := Rank(tr, v); got != expected {
+					t.Errorf("expected rank %d for %d, got %d", expected, v, got)
+				}
+			}
+			for k, v := range sorted {
+				if n := Select(tr, k); n == nil || n.Value() != v {
+					t.Errorf("expected %d at position %d, got %v", v, k, n)
+				}
+			}
+			if Select(tr, len(sorted)) != nil || Select(tr, -1) != nil {
+				t.Errorf("expected nil for positions out of range")
+			}
+		})
+	}
+}
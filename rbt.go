@@ -3,6 +3,7 @@ package tree
 import (
 	"cmp"
 	"errors"
+	"math/bits"
 )
 
 const (
@@ -10,159 +11,147 @@ const (
 	_COLOR_BLACK = "black"
 )
 
-type RBT[T cmp.Ordered] struct {
-	root *RBTNode[T]
+// RBT is a red black tree.
+//
+// The zero value is not usable, trees must be created with NewRBT, NewRBTFunc,
+// NewMultiRBT or NewMultiRBTFunc.
+type RBT[T any] struct {
+	root *rbtNode[T]
 	size int
-	tnil *RBTNode[T]
+	// cmp orders the values stored in the tree.
+	cmp func(a, b T) int
+	// multi allows storing the same value multiple times.
+	multi bool
+	// augment, if set, is called every time the size of a node is recomputed,
+	// so that other per-node data derived from the subtree can be maintained
+	// alongside it.
+	augment func(x *rbtNode[T])
+	// kind identifies the trees created by the same call to NewRBTFunc or
+	// NewMultiRBTFunc, which order their values the same way, see Join. It is
+	// nil for trees ordered by cmp.Compare.
+	kind *rbtKind
+	// owner identifies the nodes the tree may modify, since the others are
+	// shared with its clones.
+	owner *cowOwner
+	// mods counts the modifications of the tree, which allows iterators to
+	// detect modifications during iteration.
+	mods int
 }
 
 // NewRBT returns an initialized red black tree.
 func NewRBT[T cmp.Ordered]() *RBT[T] {
-	tnil := sentinel[T]()
 	return &RBT[T]{
 		size: 0,
-		root: tnil,
-		tnil: tnil,
+		root: nil,
+		cmp:  cmp.Compare[T],
+	}
+}
+
+// NewRBTFunc returns an initialized red black tree that orders its values using
+// cmp, which should return a negative number when a < b, a positive number when
+// a > b and zero when a == b.
+func NewRBTFunc[T any](cmp func(a, b T) int) *RBT[T] {
+	return &RBT[T]{
+		size: 0,
+		root: nil,
+		cmp:  cmp,
+		kind: &rbtKind{},
 	}
 }
 
+// NewMultiRBT returns an initialized red black tree that allows storing the
+// same value multiple times. Inserting an existing value increments the count
+// of its node, and deleting it decrements the count before removing the node.
+func NewMultiRBT[T cmp.Ordered]() *RBT[T] {
+	t := NewRBT[T]()
+	t.multi = true
+	return t
+}
+
+// NewMultiRBTFunc is like NewMultiRBT, but orders values using cmp. See
+// NewRBTFunc.
+func NewMultiRBTFunc[T any](cmp func(a, b T) int) *RBT[T] {
+	t := NewRBTFunc(cmp)
+	t.multi = true
+	return t
+}
+
+// rbtKind is the identity of trees ordering their values the same way.
+type rbtKind struct {
+	// distinct pointers to zero-size values may be equal, so kinds take up
+	// some space.
+	_ byte
+}
+
 func (t *RBT[T]) Root() Node[T] {
 	panicIfNilTree(t)
 
-	if t.root.isSentinel() {
+	if t.root == nil {
 		return nil
 	}
-	return t.root
+	return &RBTNode[T]{n: t.root}
 }
 
+// Size returns the number of elements in the tree, counting duplicates.
 func (t *RBT[T]) Size() int {
 	panicIfNilTree(t)
 
 	return t.size
 }
 
+// Distinct returns the number of distinct values in the tree. This is the same
+// as Size unless the tree is a multiset.
+func (t *RBT[T]) Distinct() int {
+	panicIfNilTree(t)
+
+	return rbtDistinct(t.root)
+}
+
 func (t *RBT[T]) Count(value T) int {
 	panicIfNilTree(t)
+	panicIfUninitialized(t.cmp)
 
-	if t.root == nil {
-		return 0
-	}
-	c := t.root
-	for c != nil {
-		if value < c.value {
-			c = c.left
-		} else if value > c.value {
-			c = c.right
-		} else {
-			return c.Count()
-		}
+	if c := rbtFind(t, value); c != nil {
+		return c.count
 	}
 	return 0
 }
 
 func (t *RBT[T]) Insert(value T) error {
 	panicIfNilTree(t)
+	panicIfUninitialized(t.cmp)
 
-	if t.root == t.tnil {
-		t.root = &RBTNode[T]{
-			parent: t.tnil,
-			left:   t.tnil,
-			right:  t.tnil,
-			value:  value,
-			color:  _COLOR_BLACK,
-		}
-		t.size = 1
-		return nil
-	}
-
-	y := t.tnil
-	x := t.root
-	z := &RBTNode[T]{
-		value: value,
+	if _, ok := rbtInsert(t, value); !ok {
+		return errors.New("value already exists")
 	}
-
-	for x != t.tnil {
-		y = x
-		if z.value < x.value {
-			x = x.left
-		} else if z.value > x.value {
-			x = x.right
-		} else {
-			return errors.New("value already exists")
-		}
-	}
-	z.parent = y
-
-	if y == t.tnil {
-		t.root = z
-	} else if z.value < y.value {
-		y.left = z
-	} else {
-		y.right = z
-	}
-	z.left = t.tnil
-	z.right = t.tnil
-	z.color = _COLOR_RED
-
-	insertFixup(t, z)
-	t.size++
-
 	return nil
 }
 
 func (t *RBT[T]) Delete(value T) error {
 	panicIfNilTree(t)
+	panicIfUninitialized(t.cmp)
 
-	// uninitialized tree
-	if t.root == nil {
+	if rbtFind(t, value) == nil {
 		return errors.New("value not found")
 	}
 
-	// find z
-	z := t.root
-	for z != t.tnil {
-		if value < z.value {
-			z = z.left
-		} else if value > z.value {
-			z = z.right
+	cowPrepare(&t.owner)
+	path := []*rbtNode[T]{}
+	link := &t.root
+	for {
+		z := rbtOwn(t, *link)
+		*link = z
+		path = append(path, z)
+		if o := t.cmp(value, z.value); o < 0 {
+			link = &z.left
+		} else if o > 0 {
+			link = &z.right
 		} else {
 			break
 		}
 	}
-	if z == t.tnil {
-		return errors.New("value not found")
-	}
 
-	y := z
-	yorigcolor := y.color
-	var x *RBTNode[T]
-
-	if z.left == t.tnil {
-		x = z.right
-		rbtransplant(t, z, z.right)
-	} else if z.right == t.tnil {
-		x = z.left
-		rbtransplant(t, z, z.left)
-	} else {
-		y = treeMinimumRbt(t, z.right)
-		yorigcolor = y.color
-		x = y.right
-		if y.parent == z {
-			x.parent = y
-		} else {
-			rbtransplant(t, y, y.right)
-			y.right = z.right
-			y.right.parent = y
-		}
-		rbtransplant(t, z, y)
-		y.left = z.left
-		y.left.parent = y
-		y.color = z.color
-	}
-	if yorigcolor == _COLOR_BLACK {
-		rbDeleteFixup(t, x)
-	}
-	t.size--
+	rbtDeleteNode(t, path)
 	return nil
 }
 
@@ -172,229 +161,527 @@ func (t *RBT[T]) String() string {
 	return FormatTree(t, string(FormatHorizontal))
 }
 
-type RBTNode[T cmp.Ordered] struct {
+func (t *RBT[T]) modifications() int {
+	return t.mods
+}
+
+// rbtNode is a node of an RBT. Nodes don't link to their parents, so that
+// clones can share them, see Clone.
+type rbtNode[T any] struct {
+	left  *rbtNode[T]
+	right *rbtNode[T]
+	value T
+	// count is the number of occurences of value, which can only exceed 1 in
+	// multisets.
+	count int
+	// size is the number of elements stored in the subtree rooted at this
+	// node, counting duplicates.
+	size int
+	// distinct is the number of nodes in the subtree rooted at this node,
+	// which differs from size only for multisets.
+	distinct int
+	color    string
+	// owner identifies the trees that may modify the node.
+	owner *cowOwner
+}
+
+// RBTNode is a node of an RBT, as reached from the root of the tree. It
+// remembers the node it was reached from, which is its parent, so retrieving
+// the same node twice results in two different RBTNode values.
+type RBTNode[T any] struct {
+	n      *rbtNode[T]
 	parent *RBTNode[T]
-	left   *RBTNode[T]
-	right  *RBTNode[T]
-	value  T
-	color  string
 }
 
 func (n *RBTNode[T]) Value() T {
-	panicIfNilOrSentinelNode(n)
+	panicIfNilRBTNode(n)
 
-	return n.value
+	return n.n.value
 }
 
 func (n *RBTNode[T]) Count() int {
-	panicIfNilOrSentinelNode(n)
+	panicIfNilRBTNode(n)
 
-	return 1
+	return n.n.count
+}
+
+func (n *RBTNode[T]) SubtreeSize() int {
+	panicIfNilRBTNode(n)
+
+	return n.n.size
 }
 
 func (n *RBTNode[T]) Parent() Node[T] {
-	panicIfNilOrSentinelNode(n)
+	panicIfNilRBTNode(n)
 
-	if n.parent.isSentinel() {
+	if n.parent == nil {
 		return nil
 	}
 	return n.parent
 }
 
 func (n *RBTNode[T]) Left() Node[T] {
-	panicIfNilOrSentinelNode(n)
+	panicIfNilRBTNode(n)
 
-	if n.left.isSentinel() {
+	if n.n.left == nil {
 		return nil
 	}
-	return n.left
+	return &RBTNode[T]{n: n.n.left, parent: n}
 }
 
 func (n *RBTNode[T]) Right() Node[T] {
-	panicIfNilOrSentinelNode(n)
+	panicIfNilRBTNode(n)
 
-	if n.left.isSentinel() {
+	if n.n.right == nil {
 		return nil
 	}
-	return n.right
-}
-
-func (n *RBTNode[T]) isSentinel() bool {
-	// all children of non-sentinel rbt nodes are represented as tnil.
-	return n.left == nil && n.right == nil
+	return &RBTNode[T]{n: n.n.right, parent: n}
 }
 
 // ttycolor is used for colored terminal output.
 func (n *RBTNode[T]) ttycolor() string {
 	panicIfNilNode(n)
 
-	return n.color
+	return n.n.color
 }
 
-// sentinel value
-func sentinel[T cmp.Ordered]() *RBTNode[T] {
-	return &RBTNode[T]{
-		color: _COLOR_BLACK,
-	}
+func (n *RBTNode[T]) viewed() any {
+	return n.n
 }
 
-// panicIfNilOrSentinelNode will panic if the current node is nil or a sentinel.
-// Sentinels are included because they are an implementation detail to simplify
-// edge case handling in algorithms, but in essence they still represent nil
-// nodes and should be exposed as nil from public methods.
-//
-// Note that this method is typically called for public methods, as algorithms
-// use the internal representation directly.
-func panicIfNilOrSentinelNode[T cmp.Ordered](n *RBTNode[T]) {
+// panicIfNilRBTNode will panic if the current node is nil. Nil nodes can't be
+// detected by panicIfNilNode once converted to a Node.
+func panicIfNilRBTNode[T any](n *RBTNode[T]) {
 	if n == nil {
 		panic("nil node")
-	} else if n.isSentinel() {
-		panic("nil node")
 	}
 }
 
-func leftRotate[T cmp.Ordered](t *RBT[T], x *RBTNode[T]) {
-	y := x.right
-	x.right = y.left
-	if y.left != t.tnil {
-		y.left.parent = x
-	}
-	y.parent = x.parent
-	if x.parent == t.tnil {
-		t.root = y
-	} else if x == x.parent.left {
-		x.parent.left = y
+// rbtIsRed returns whether x is red. Missing children are black leaves.
+func rbtIsRed[T any](x *rbtNode[T]) bool {
+	return x != nil && x.color == _COLOR_RED
+}
+
+// rbtSize returns the number of elements stored in the subtree rooted at x.
+func rbtSize[T any](x *rbtNode[T]) int {
+	if x == nil {
+		return 0
+	}
+	return x.size
+}
+
+// rbtDistinct returns the number of nodes in the subtree rooted at x.
+func rbtDistinct[T any](x *rbtNode[T]) int {
+	if x == nil {
+		return 0
+	}
+	return x.distinct
+}
+
+// rbtReplace replaces the child old of p with x, or the root of the tree if p
+// is nil.
+func rbtReplace[T any](t *RBT[T], p *rbtNode[T], old *rbtNode[T], x *rbtNode[T]) {
+	if p == nil {
+		t.root = x
+	} else if p.left == old {
+		p.left = x
 	} else {
-		x.parent.right = y
+		p.right = x
 	}
+}
+
+// leftRotate rotates x, whose parent is p, to the left. Both x and its right
+// child must be modifiable by the tree, and so must p unless it is nil.
+func leftRotate[T any](t *RBT[T], p *rbtNode[T], x *rbtNode[T]) {
+	y := x.right
+	x.right = y.left
+	rbtReplace(t, p, x, y)
 	y.left = x
-	x.parent = y
+
+	rbtUpdate(t, x)
+	rbtUpdate(t, y)
 }
 
-func rightRotate[T cmp.Ordered](t *RBT[T], y *RBTNode[T]) {
+// rightRotate is symmetric to leftRotate.
+func rightRotate[T any](t *RBT[T], p *rbtNode[T], y *rbtNode[T]) {
 	x := y.left
 	y.left = x.right
-	if x.right != t.tnil {
-		x.right.parent = y
+	rbtReplace(t, p, y, x)
+	x.right = y
+
+	rbtUpdate(t, y)
+	rbtUpdate(t, x)
+}
+
+// rbtInsert adds value to the tree, or increments the count of the node storing
+// it in multisets. It returns the node storing value, which the tree may
+// modify, and false if value was already stored in a tree that is not a
+// multiset, in which case the elements of the tree are left unchanged.
+func rbtInsert[T any](t *RBT[T], value T) (*rbtNode[T], bool) {
+	cowPrepare(&t.owner)
+
+	path := []*rbtNode[T]{}
+	link := &t.root
+	for *link != nil {
+		x := rbtOwn(t, *link)
+		*link = x
+		path = append(path, x)
+		if o := t.cmp(value, x.value); o < 0 {
+			link = &x.left
+		} else if o > 0 {
+			link = &x.right
+		} else if t.multi {
+			x.count++
+			rbtUpdatePath(t, path)
+			t.size++
+			t.mods++
+			return x, true
+		} else {
+			return x, false
+		}
 	}
-	x.parent = y.parent
-	if y.parent == t.tnil {
-		t.root = x
-	} else if y == y.parent.left {
-		y.parent.left = x
-	} else {
-		y.parent.right = x
+
+	z := &rbtNode[T]{
+		left:  nil,
+		right: nil,
+		value: value,
+		count: 1,
+		color: _COLOR_RED,
+		owner: t.owner,
 	}
-	x.right = y
-	y.parent = x
+	*link = z
+	path = append(path, z)
+
+	rbtUpdatePath(t, path)
+	insertFixup(t, path)
+	t.size++
+	t.mods++
+
+	return z, true
 }
 
-// transplant replaces one subtree with another subtree
-func rbtransplant[T cmp.Ordered](t *RBT[T], u *RBTNode[T], v *RBTNode[T]) {
-	// u is root
-	if u.parent == t.tnil {
-		t.root = v
-	} else if u == u.parent.left {
-		u.parent.left = v
+// rbtDeleteNode removes one occurence of the value stored in the last node of
+// path, which leads from the root of the tree through nodes the tree may
+// modify.
+func rbtDeleteNode[T any](t *RBT[T], path []*rbtNode[T]) {
+	t.size--
+	t.mods++
+	k := len(path) - 1
+	z := path[k]
+	if z.count > 1 {
+		z.count--
+		rbtUpdatePath(t, path)
+		return
+	}
+
+	var zp *rbtNode[T]
+	if k > 0 {
+		zp = path[k-1]
+	}
+	yorigcolor := z.color
+	var x *rbtNode[T]
+
+	if z.left == nil {
+		x = z.right
+		rbtReplace(t, zp, z, x)
+		path = path[:k]
+	} else if z.right == nil {
+		x = z.left
+		rbtReplace(t, zp, z, x)
+		path = path[:k]
 	} else {
-		u.parent.right = v
+		// y is the minimum of the right subtree of z, and takes its place. The
+		// path then leads through y down to the parent of x.
+		path = append(path[:k], nil)
+		y := rbtOwn(t, z.right)
+		z.right = y
+		for y.left != nil {
+			path = append(path, y)
+			y.left = rbtOwn(t, y.left)
+			y = y.left
+		}
+		path[k] = y
+		yorigcolor = y.color
+		x = y.right
+		if len(path) > k+1 {
+			path[len(path)-1].left = y.right
+			y.right = z.right
+		}
+		rbtReplace(t, zp, z, y)
+		y.left = z.left
+		y.color = z.color
+	}
+	// the path leads to the lowest node whose subtree lost an element.
+	rbtUpdatePath(t, path)
+	if yorigcolor == _COLOR_BLACK {
+		rbDeleteFixup(t, x, path)
+	}
+}
+
+// rbtUpdate recomputes the size of x from its children, and runs the
+// augmentation hook of the tree.
+func rbtUpdate[T any](t *RBT[T], x *rbtNode[T]) {
+	x.size = rbtSize(x.left) + rbtSize(x.right) + x.count
+	x.distinct = rbtDistinct(x.left) + rbtDistinct(x.right) + 1
+	if t.augment != nil {
+		t.augment(x)
+	}
+}
+
+// rbtUpdatePath recomputes the sizes of the nodes of path, which leads from
+// the root of the tree, from the bottom up.
+func rbtUpdatePath[T any](t *RBT[T], path []*rbtNode[T]) {
+	for i := len(path) - 1; i >= 0; i-- {
+		rbtUpdate(t, path[i])
+	}
+}
+
+// rbtFind returns the node storing value, or nil if there is no such node.
+func rbtFind[T any](t *RBT[T], value T) *rbtNode[T] {
+	x := t.root
+	for x != nil {
+		if o := t.cmp(value, x.value); o < 0 {
+			x = x.left
+		} else if o > 0 {
+			x = x.right
+		} else {
+			return x
+		}
 	}
-	v.parent = u.parent
+	return nil
 }
 
-func treeMinimumRbt[T cmp.Ordered](t *RBT[T], x *RBTNode[T]) *RBTNode[T] {
-	for x.left != t.tnil {
+func treeMinimumRbt[T any](x *rbtNode[T]) *rbtNode[T] {
+	for x.left != nil {
 		x = x.left
 	}
 	return x
 }
 
-func insertFixup[T cmp.Ordered](t *RBT[T], z *RBTNode[T]) {
-	for z.parent.color == _COLOR_RED {
-		if z.parent == z.parent.parent.left {
-			y := z.parent.parent.right
-			if y.color == _COLOR_RED {
-				z.parent.color = _COLOR_BLACK
+func treeMaximumRbt[T any](x *rbtNode[T]) *rbtNode[T] {
+	for x.right != nil {
+		x = x.right
+	}
+	return x
+}
+
+// rbtView returns a view of the last node of path, which leads from the root
+// of the tree, or nil if path is empty. All views on the path are allocated at
+// once.
+func rbtView[T any](path []*rbtNode[T]) *RBTNode[T] {
+	if len(path) == 0 {
+		return nil
+	}
+	views := make([]RBTNode[T], len(path))
+	for i, x := range path {
+		views[i].n = x
+		if i > 0 {
+			views[i].parent = &views[i-1]
+		}
+	}
+	return &views[len(views)-1]
+}
+
+// rbtOwnPath appends to path the nodes from the root of the tree down to the
+// node viewed by n, replacing the ones the tree may not modify by copies. The
+// tree must not have been modified since n was retrieved.
+func rbtOwnPath[T any](t *RBT[T], n *RBTNode[T], path []*rbtNode[T]) []*rbtNode[T] {
+	if n.parent == nil {
+		t.root = rbtOwn(t, t.root)
+		return append(path, t.root)
+	}
+	path = rbtOwnPath(t, n.parent, path)
+	// the copy of the parent has the same children as the parent n was
+	// reached from.
+	p := path[len(path)-1]
+	if n.parent.n.left == n.n {
+		p.left = rbtOwn(t, p.left)
+		return append(path, p.left)
+	}
+	p.right = rbtOwn(t, p.right)
+	return append(path, p.right)
+}
+
+// rbtSelectPath appends to path the nodes from the root of the tree down to the
+// node storing the k-th smallest element, counting from 0. It returns nil if k
+// is out of range.
+func rbtSelectPath[T any](t *RBT[T], k int, path []*rbtNode[T]) []*rbtNode[T] {
+	for x := t.root; x != nil; {
+		path = append(path, x)
+		ls := rbtSize(x.left)
+		if k < ls {
+			x = x.left
+		} else if k < ls+x.count {
+			return path
+		} else {
+			k -= ls + x.count
+			x = x.right
+		}
+	}
+	return nil
+}
+
+// rbtDeleteView removes one occurence of the value stored in the node viewed
+// by n, which must belong to the tree. It returns a view of the node storing
+// the following element, or nil if there is none.
+func rbtDeleteView[T any](t *RBT[T], n *RBTNode[T]) *RBTNode[T] {
+	cowPrepare(&t.owner)
+	// the height of the tree is at most 2*lg(n+1), so the path never grows.
+	path := rbtOwnPath(t, n, make([]*rbtNode[T], 0, 2*bits.Len(uint(t.size))))
+	z := path[len(path)-1]
+	if z.count > 1 {
+		rbtDeleteNode(t, path)
+		return rbtView(path)
+	}
+
+	// the following element takes the rank of the deleted one.
+	rank := rbtSize(z.left)
+	for i := len(path) - 2; i >= 0; i-- {
+		if path[i].right == path[i+1] {
+			rank += rbtSize(path[i].left) + path[i].count
+		}
+	}
+	rbtDeleteNode(t, path)
+	return rbtView(rbtSelectPath(t, rank, path[:0]))
+}
+
+// insertFixup restores the red black properties after the last node of path,
+// which leads from the root of the tree through nodes the tree may modify, was
+// colored red. It returns whether the black height of the tree grew, which
+// happens when the root has to be colored black.
+func insertFixup[T any](t *RBT[T], path []*rbtNode[T]) bool {
+	k := len(path) - 1
+	// the parent of z is never the root while it is red, so z has a
+	// grandparent g.
+	for k > 0 && rbtIsRed(path[k-1]) {
+		z, zp, g := path[k], path[k-1], path[k-2]
+		var gp *rbtNode[T]
+		if k > 2 {
+			gp = path[k-3]
+		}
+		if zp == g.left {
+			y := g.right
+			if rbtIsRed(y) {
+				y = rbtOwn(t, y)
+				g.right = y
+				zp.color = _COLOR_BLACK
 				y.color = _COLOR_BLACK
-				z.parent.parent.color = _COLOR_RED
-				z = z.parent.parent
-			} else if z == z.parent.right {
-				z = z.parent
-				leftRotate(t, z)
-			} else {
-				z.parent.color = _COLOR_BLACK
-				z.parent.parent.color = _COLOR_RED
-				rightRotate(t, z.parent.parent)
+				g.color = _COLOR_RED
+				k -= 2
+				continue
+			}
+			if z == zp.right {
+				leftRotate(t, g, zp)
+				zp = z
 			}
+			zp.color = _COLOR_BLACK
+			g.color = _COLOR_RED
+			rightRotate(t, gp, g)
 		} else {
-			y := z.parent.parent.left
-			if y.color == _COLOR_RED {
-				z.parent.color = _COLOR_BLACK
+			y := g.left
+			if rbtIsRed(y) {
+				y = rbtOwn(t, y)
+				g.left = y
+				zp.color = _COLOR_BLACK
 				y.color = _COLOR_BLACK
-				z.parent.parent.color = _COLOR_RED
-				z = z.parent.parent
-			} else if z == z.parent.left {
-				z = z.parent
-				rightRotate(t, z)
-			} else {
-				z.parent.color = _COLOR_BLACK
-				z.parent.parent.color = _COLOR_RED
-				leftRotate(t, z.parent.parent)
+				g.color = _COLOR_RED
+				k -= 2
+				continue
+			}
+			if z == zp.left {
+				rightRotate(t, g, zp)
+				zp = z
 			}
+			zp.color = _COLOR_BLACK
+			g.color = _COLOR_RED
+			leftRotate(t, gp, g)
 		}
+		break
 	}
+	grew := rbtIsRed(t.root)
 	t.root.color = _COLOR_BLACK
+	return grew
 }
 
-func rbDeleteFixup[T cmp.Ordered](t *RBT[T], x *RBTNode[T]) {
-	for x != t.root && x.color == _COLOR_BLACK {
-		if x == x.parent.left {
-			w := x.parent.right
-			if w.color == _COLOR_RED {
+// rbDeleteFixup restores the red black properties after deleting a black node,
+// where x took the place of the node that was moved or removed. path leads from
+// the root of the tree to the parent of x, through nodes the tree may modify.
+func rbDeleteFixup[T any](t *RBT[T], x *rbtNode[T], path []*rbtNode[T]) {
+	for len(path) > 0 && !rbtIsRed(x) {
+		k := len(path) - 1
+		xp := path[k]
+		var g *rbtNode[T]
+		if k > 0 {
+			g = path[k-1]
+		}
+		if x == xp.left {
+			xp.right = rbtOwn(t, xp.right)
+			w := xp.right
+			if rbtIsRed(w) {
 				w.color = _COLOR_BLACK
-				x.parent.color = _COLOR_RED
-				leftRotate(t, x.parent)
-				w = x.parent.right
+				xp.color = _COLOR_RED
+				leftRotate(t, g, xp)
+				path = append(path[:k], w, xp)
+				k, g = k+1, w
+				xp.right = rbtOwn(t, xp.right)
+				w = xp.right
 			}
-			if w.left.color == _COLOR_BLACK && w.right.color == _COLOR_BLACK {
+			if !rbtIsRed(w.left) && !rbtIsRed(w.right) {
 				w.color = _COLOR_RED
-				x = x.parent
-			} else if w.right.color == _COLOR_BLACK {
+				x, path = xp, path[:k]
+				continue
+			}
+			if !rbtIsRed(w.right) {
+				w.left = rbtOwn(t, w.left)
 				w.left.color = _COLOR_BLACK
 				w.color = _COLOR_RED
-				rightRotate(t, w)
-				w = x.parent.right
-			} else {
-				w.color = x.parent.color
-				x.parent.color = _COLOR_BLACK
-				w.right.color = _COLOR_BLACK
-				leftRotate(t, x.parent)
-				x = t.root
+				rightRotate(t, xp, w)
+				w = xp.right
 			}
+			w.color = xp.color
+			xp.color = _COLOR_BLACK
+			w.right = rbtOwn(t, w.right)
+			w.right.color = _COLOR_BLACK
+			leftRotate(t, g, xp)
 		} else {
-			w := x.parent.left
-			if w.color == _COLOR_RED {
+			xp.left = rbtOwn(t, xp.left)
+			w := xp.left
+			if rbtIsRed(w) {
 				w.color = _COLOR_BLACK
-				x.parent.color = _COLOR_RED
-				rightRotate(t, x.parent)
-				w = x.parent.left
+				xp.color = _COLOR_RED
+				rightRotate(t, g, xp)
+				path = append(path[:k], w, xp)
+				k, g = k+1, w
+				xp.left = rbtOwn(t, xp.left)
+				w = xp.left
 			}
-			if w.right.color == _COLOR_BLACK && w.left.color == _COLOR_BLACK {
+			if !rbtIsRed(w.right) && !rbtIsRed(w.left) {
 				w.color = _COLOR_RED
-				x = x.parent
-			} else if w.left.color == _COLOR_BLACK {
+				x, path = xp, path[:k]
+				continue
+			}
+			if !rbtIsRed(w.left) {
+				w.right = rbtOwn(t, w.right)
 				w.right.color = _COLOR_BLACK
 				w.color = _COLOR_RED
-				leftRotate(t, w)
-				w = x.parent.left
-			} else {
-				w.color = x.parent.color
-				x.parent.color = _COLOR_BLACK
-				w.left.color = _COLOR_BLACK
-				rightRotate(t, x.parent)
-				x = t.root
+				leftRotate(t, xp, w)
+				w = xp.left
 			}
+			w.color = xp.color
+			xp.color = _COLOR_BLACK
+			w.left = rbtOwn(t, w.left)
+			w.left.color = _COLOR_BLACK
+			rightRotate(t, g, xp)
+		}
+		x, path = t.root, nil
+	}
+	if rbtIsRed(x) {
+		var p *rbtNode[T]
+		if len(path) > 0 {
+			p = path[len(path)-1]
 		}
+		c := rbtOwn(t, x)
+		rbtReplace(t, p, x, c)
+		c.color = _COLOR_BLACK
 	}
-	x.color = _COLOR_BLACK
 }
@@ -0,0 +1,327 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// DefaultScapegoatAlpha is the balance factor used by NewScapegoat.
+const DefaultScapegoatAlpha = 0.7
+
+// Scapegoat is a balanced binary search tree that does not store any balance
+// information in its nodes. Instead, when an insertion produces a node that is
+// too deep, the subtree rooted at an unbalanced ancestor (the scapegoat) is
+// rebuilt into a perfectly balanced one.
+type Scapegoat[T cmp.Ordered] struct {
+	root *ScapegoatNode[T]
+	size int
+	// maxSize is the largest size the tree had since it was last rebuilt
+	// completely.
+	maxSize int
+	alpha   float64
+}
+
+// NewScapegoat returns an initialized scapegoat tree using
+// DefaultScapegoatAlpha.
+func NewScapegoat[T cmp.Ordered]() *Scapegoat[T] {
+	return NewScapegoatWithAlpha[T](DefaultScapegoatAlpha)
+}
+
+// NewScapegoatWithAlpha returns an initialized scapegoat tree with the provided
+// balance factor, which must be in the range [0.5, 1). Lower values keep the
+// tree closer to perfectly balanced at the cost of more frequent rebuilds.
+func NewScapegoatWithAlpha[T cmp.Ordered](alpha float64) *Scapegoat[T] {
+	if alpha < 0.5 || alpha >= 1 {
+		panic(fmt.Sprintf("scapegoat alpha must be in [0.5, 1), got %v", alpha))
+	}
+	return &Scapegoat[T]{
+		size:    0,
+		root:    nil,
+		maxSize: 0,
+		alpha:   alpha,
+	}
+}
+
+func (t *Scapegoat[T]) Root() Node[T] {
+	panicIfNilTree(t)
+
+	if t.root == nil {
+		return nil
+	}
+	return t.root
+}
+
+func (t *Scapegoat[T]) Size() int {
+	panicIfNilTree(t)
+
+	return t.size
+}
+
+func (t *Scapegoat[T]) Count(value T) int {
+	panicIfNilTree(t)
+
+	c := t.root
+	for c != nil {
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return c.Count()
+		}
+	}
+	return 0
+}
+
+func (t *Scapegoat[T]) Insert(value T) error {
+	panicIfNilTree(t)
+
+	var y *ScapegoatNode[T] = nil
+	c := t.root
+	depth := 0
+	for c != nil {
+		y = c
+		depth++
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return errors.New("value already exists")
+		}
+	}
+
+	z := &ScapegoatNode[T]{
+		parent: y,
+		value:  value,
+	}
+	if y == nil {
+		t.root = z
+	} else if value < y.value {
+		y.left = z
+	} else {
+		y.right = z
+	}
+	t.size++
+	t.maxSize = max(t.maxSize, t.size)
+
+	if depth <= scapegoatMaxDepth(t.size, t.alpha) {
+		return nil
+	}
+
+	// the new node is too deep, which means that one of its ancestors must be
+	// unbalanced. Walk up to find it.
+	x := z
+	xsize := 1
+	for x.parent != nil {
+		p := x.parent
+		var sibling *ScapegoatNode[T]
+		if x == p.left {
+			sibling = p.right
+		} else {
+			sibling = p.left
+		}
+		psize := xsize + scapegoatSubtreeSize(sibling) + 1
+		if float64(xsize) > t.alpha*float64(psize) {
+			scapegoatRebuild(t, p, psize)
+			return nil
+		}
+		x = p
+		xsize = psize
+	}
+	return nil
+}
+
+func (t *Scapegoat[T]) Delete(value T) error {
+	panicIfNilTree(t)
+
+	// find z
+	z := t.root
+	for z != nil {
+		if value < z.value {
+			z = z.left
+		} else if value > z.value {
+			z = z.right
+		} else {
+			break
+		}
+	}
+	if z == nil {
+		return errors.New("value not found")
+	}
+
+	if z.left == nil {
+		scapegoattransplant(t, z, z.right)
+	} else if z.right == nil {
+		scapegoattransplant(t, z, z.left)
+	} else {
+		y := treeMinimumScapegoat(z.right)
+		if y.parent != z {
+			scapegoattransplant(t, y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		scapegoattransplant(t, z, y)
+		y.left = z.left
+		y.left.parent = y
+	}
+	t.size--
+
+	if float64(t.size) < t.alpha*float64(t.maxSize) {
+		if t.root != nil {
+			scapegoatRebuild(t, t.root, t.size)
+		}
+		t.maxSize = t.size
+	}
+	return nil
+}
+
+func (t *Scapegoat[T]) String() string {
+	panicIfNilTree(t)
+
+	return FormatTree(t, string(FormatHorizontal))
+}
+
+type ScapegoatNode[T cmp.Ordered] struct {
+	parent *ScapegoatNode[T]
+	left   *ScapegoatNode[T]
+	right  *ScapegoatNode[T]
+	value  T
+}
+
+func (n *ScapegoatNode[T]) Parent() Node[T] {
+	panicIfNilNode(n)
+
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *ScapegoatNode[T]) Left() Node[T] {
+	panicIfNilNode(n)
+
+	if n.left == nil {
+		return nil
+	}
+	return n.left
+}
+
+func (n *ScapegoatNode[T]) Right() Node[T] {
+	panicIfNilNode(n)
+
+	if n.right == nil {
+		return nil
+	}
+	return n.right
+}
+
+func (n *ScapegoatNode[T]) Value() T {
+	panicIfNilNode(n)
+
+	return n.value
+}
+
+func (n *ScapegoatNode[T]) Count() int {
+	panicIfNilNode(n)
+
+	return 1
+}
+
+// Tree helpers
+
+// scapegoatMaxDepth returns the maximum depth allowed for a tree of size n,
+// which is floor(log_{1/alpha}(n)).
+func scapegoatMaxDepth(n int, alpha float64) int {
+	return int(math.Floor(math.Log(float64(n)) / math.Log(1/alpha)))
+}
+
+// scapegoatSubtreeSize counts the nodes of the subtree rooted at n. Since nodes
+// don't store their sizes, this takes time proportional to the size of the
+// subtree.
+func scapegoatSubtreeSize[T cmp.Ordered](n *ScapegoatNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	size := 0
+	stack := []*ScapegoatNode[T]{n}
+	for len(stack) != 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		size++
+		if c.left != nil {
+			stack = append(stack, c.left)
+		}
+		if c.right != nil {
+			stack = append(stack, c.right)
+		}
+	}
+	return size
+}
+
+// scapegoatRebuild replaces the subtree rooted at n, which has size nodes, with
+// a perfectly balanced subtree storing the same nodes.
+func scapegoatRebuild[T cmp.Ordered](t *Scapegoat[T], n *ScapegoatNode[T], size int) {
+	// flatten the subtree in order.
+	nodes := make([]*ScapegoatNode[T], 0, size)
+	stack := []*ScapegoatNode[T]{}
+	c := n
+	for c != nil || len(stack) != 0 {
+		for c != nil {
+			stack = append(stack, c)
+			c = c.left
+		}
+		c = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		nodes = append(nodes, c)
+		c = c.right
+	}
+
+	parent := n.parent
+	root := scapegoatBuild(nodes, parent)
+	if parent == nil {
+		t.root = root
+	} else if parent.left == n {
+		parent.left = root
+	} else {
+		parent.right = root
+	}
+}
+
+// scapegoatBuild links the sorted nodes into a balanced subtree hanging from
+// parent and returns its root.
+func scapegoatBuild[T cmp.Ordered](nodes []*ScapegoatNode[T], parent *ScapegoatNode[T]) *ScapegoatNode[T] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	root := nodes[mid]
+	root.parent = parent
+	root.left = scapegoatBuild(nodes[:mid], root)
+	root.right = scapegoatBuild(nodes[mid+1:], root)
+	return root
+}
+
+// transplant replaces one subtree with another subtree
+func scapegoattransplant[T cmp.Ordered](t *Scapegoat[T], u *ScapegoatNode[T], v *ScapegoatNode[T]) {
+	// u is root
+	if u.parent == nil {
+		t.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+func treeMinimumScapegoat[T cmp.Ordered](x *ScapegoatNode[T]) *ScapegoatNode[T] {
+	for x.left != nil {
+		x = x.left
+	}
+	return x
+}
@@ -0,0 +1,41 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestScapegoatHeight(t *testing.T) {
+	for _, alpha := range []float64{0.5, DefaultScapegoatAlpha, 0.9} {
+		tr := NewScapegoatWithAlpha[int](alpha)
+
+		// consecutive values degenerate a regular BST into a list.
+		for i := range 1000 {
+			tr.Insert(i)
+			if h := height[int](tr.Root()); h-1 > scapegoatMaxDepth(tr.Size(), alpha) {
+				t.Fatalf("alpha %v: height %d exceeds the bound for %d nodes", alpha, h, tr.Size())
+			}
+		}
+		checkParentLinks[int](t, tr.Root())
+
+		r := rand.New(rand.NewSource(11))
+		for _, v := range r.Perm(1000)[:900] {
+			if err := tr.Delete(v); err != nil {
+				t.Fatalf("alpha %v: expected %d to be in tree, got error %s", alpha, v, err.Error())
+			}
+		}
+		checkParentLinks[int](t, tr.Root())
+		if h := height[int](tr.Root()); h-1 > scapegoatMaxDepth(tr.maxSize, alpha)+1 {
+			t.Errorf("alpha %v: height %d too large after deletions for %d nodes", alpha, h, tr.Size())
+		}
+	}
+}
+
+func TestScapegoatInvalidAlpha(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for alpha outside [0.5, 1)")
+		}
+	}()
+	NewScapegoatWithAlpha[int](1)
+}
@@ -0,0 +1,284 @@
+package tree
+
+import (
+	"cmp"
+	"iter"
+)
+
+// The set operations below treat trees as sets: values stored multiple times
+// in multisets are only considered once, and are stored once in the result.
+//
+// The result is built in a new tree returned by mk, which must be empty and
+// order values the same way as the two input trees. Since trees can't be
+// rebuilt in linear time through their public interface, computing the result
+// generally takes O((n + m) lg(n + m)) time, where n and m are the sizes of
+// the two trees. Values are inserted in an order that keeps trees without
+// rebalancing balanced as well.
+//
+// If both trees and the result are red black trees, the result is instead
+// computed by splitting and joining structural copies of the input trees.
+// This avoids inserting values one by one, and takes O(n + m) time.
+
+// setOp describes a set operation by the values it keeps.
+type setOp int
+
+const (
+	opUnion setOp = iota
+	opIntersection
+	opDifference
+	opSymmetricDifference
+)
+
+// keep returns whether a value belonging to the first set if ina is true, and
+// to the second set if inb is true, belongs to the result of the operation.
+func (op setOp) keep(ina, inb bool) bool {
+	switch op {
+	case opIntersection:
+		return ina && inb
+	case opDifference:
+		return ina && !inb
+	case opSymmetricDifference:
+		return ina != inb
+	default:
+		return ina || inb
+	}
+}
+
+// Union returns a tree created by mk storing the values found in a, b or both.
+func Union[T cmp.Ordered, R Tree[T]](a, b Tree[T], mk func() R) R {
+	return UnionFunc(a, b, mk, cmp.Compare[T])
+}
+
+// Intersection returns a tree created by mk storing the values found in both a
+// and b.
+func Intersection[T cmp.Ordered, R Tree[T]](a, b Tree[T], mk func() R) R {
+	return IntersectionFunc(a, b, mk, cmp.Compare[T])
+}
+
+// Difference returns a tree created by mk storing the values found in a, but
+// not in b.
+func Difference[T cmp.Ordered, R Tree[T]](a, b Tree[T], mk func() R) R {
+	return DifferenceFunc(a, b, mk, cmp.Compare[T])
+}
+
+// SymmetricDifference returns a tree created by mk storing the values found in
+// exactly one of a and b.
+func SymmetricDifference[T cmp.Ordered, R Tree[T]](a, b Tree[T], mk func() R) R {
+	return SymmetricDifferenceFunc(a, b, mk, cmp.Compare[T])
+}
+
+// UnionFunc is like Union, but compares values using cmp, which must order
+// values the same way the trees do.
+func UnionFunc[T any, R Tree[T]](a, b Tree[T], mk func() R, cmp func(a, b T) int) R {
+	return setOperation(a, b, mk, cmp, opUnion)
+}
+
+// IntersectionFunc is like Intersection, but compares values using cmp, which
+// must order values the same way the trees do.
+func IntersectionFunc[T any, R Tree[T]](a, b Tree[T], mk func() R, cmp func(a, b T) int) R {
+	return setOperation(a, b, mk, cmp, opIntersection)
+}
+
+// DifferenceFunc is like Difference, but compares values using cmp, which must
+// order values the same way the trees do.
+func DifferenceFunc[T any, R Tree[T]](a, b Tree[T], mk func() R, cmp func(a, b T) int) R {
+	return setOperation(a, b, mk, cmp, opDifference)
+}
+
+// SymmetricDifferenceFunc is like SymmetricDifference, but compares values
+// using cmp, which must order values the same way the trees do.
+func SymmetricDifferenceFunc[T any, R Tree[T]](a, b Tree[T], mk func() R, cmp func(a, b T) int) R {
+	return setOperation(a, b, mk, cmp, opSymmetricDifference)
+}
+
+// EqualValues returns whether two trees store the same set of values,
+// regardless of their shape. Unlike Equal, this allows comparing trees of
+// different implementations.
+func EqualValues[T cmp.Ordered](a, b Tree[T]) bool {
+	return EqualValuesFunc(a, b, cmp.Compare[T])
+}
+
+// IsSubset returns whether every value of a is also stored in b.
+func IsSubset[T cmp.Ordered](a, b Tree[T]) bool {
+	return IsSubsetFunc(a, b, cmp.Compare[T])
+}
+
+// IsDisjoint returns whether a and b have no values in common.
+func IsDisjoint[T cmp.Ordered](a, b Tree[T]) bool {
+	return IsDisjointFunc(a, b, cmp.Compare[T])
+}
+
+// EqualValuesFunc is like EqualValues, but compares values using cmp, which
+// must order values the same way the trees do.
+func EqualValuesFunc[T any](a, b Tree[T], cmp func(a, b T) int) bool {
+	eq := true
+	mergeValues(a, b, cmp, func(_ T, ina, inb bool) bool {
+		eq = ina && inb
+		return eq
+	})
+	return eq
+}
+
+// IsSubsetFunc is like IsSubset, but compares values using cmp, which must
+// order values the same way the trees do.
+func IsSubsetFunc[T any](a, b Tree[T], cmp func(a, b T) int) bool {
+	subset := true
+	mergeValues(a, b, cmp, func(_ T, ina, inb bool) bool {
+		subset = !ina || inb
+		return subset
+	})
+	return subset
+}
+
+// IsDisjointFunc is like IsDisjoint, but compares values using cmp, which must
+// order values the same way the trees do.
+func IsDisjointFunc[T any](a, b Tree[T], cmp func(a, b T) int) bool {
+	disjoint := true
+	mergeValues(a, b, cmp, func(_ T, ina, inb bool) bool {
+		disjoint = !ina || !inb
+		return disjoint
+	})
+	return disjoint
+}
+
+func setOperation[T any, R Tree[T]](a, b Tree[T], mk func() R, cmp func(a, b T) int, op setOp) R {
+	panicIfNilTree(a)
+	panicIfNilTree(b)
+
+	res := mk()
+	if rres, ok := any(res).(*RBT[T]); ok && rres.size == 0 {
+		ra, aok := a.(*RBT[T])
+		rb, bok := b.(*RBT[T])
+		if aok && bok {
+			rbtSetOperation(rres, ra, rb, cmp, op)
+			return res
+		}
+	}
+
+	values := []T{}
+	mergeValues(a, b, cmp, func(v T, ina, inb bool) bool {
+		if op.keep(ina, inb) {
+			values = append(values, v)
+		}
+		return true
+	})
+	insertBalanced(res, values)
+	return res
+}
+
+// distinctValues returns an iterator over the distinct values of the tree, in
+// ascending order.
+func distinctValues[T any](t Tree[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := range AllNodes(t) {
+			if !yield(n.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// mergeValues merges the distinct values of a and b in ascending order,
+// calling fn with every value and whether it belongs to a and to b. Merging
+// stops early if fn returns false.
+func mergeValues[T any](a, b Tree[T], cmp func(a, b T) int, fn func(v T, ina, inb bool) bool) {
+	nexta, stopa := iter.Pull(distinctValues(a))
+	defer stopa()
+	nextb, stopb := iter.Pull(distinctValues(b))
+	defer stopb()
+
+	va, oka := nexta()
+	vb, okb := nextb()
+	for oka || okb {
+		var cont bool
+		switch {
+		case !okb || oka && cmp(va, vb) < 0:
+			cont = fn(va, true, false)
+			va, oka = nexta()
+		case !oka || cmp(va, vb) > 0:
+			cont = fn(vb, false, true)
+			vb, okb = nextb()
+		default:
+			cont = fn(va, true, true)
+			va, oka = nexta()
+			vb, okb = nextb()
+		}
+		if !cont {
+			return
+		}
+	}
+}
+
+// insertBalanced inserts sorted values into the tree, always inserting the
+// median first, so that the tree stays balanced even if it doesn't rebalance
+// itself.
+func insertBalanced[T any](t Tree[T], values []T) {
+	if len(values) == 0 {
+		return
+	}
+	mid := len(values) / 2
+	t.Insert(values[mid])
+	insertBalanced(t, values[:mid])
+	insertBalanced(t, values[mid+1:])
+}
+
+// rbtSetOperation stores the result of the operation on a and b in res, which
+// must be empty.
+func rbtSetOperation[T any](res, a, b *RBT[T], cmp func(a, b T) int, op setOp) {
+	cowPrepare(&res.owner)
+	// values are compared using cmp, as in the general case, so the operation
+	// runs on a scratch tree sharing everything else with res.
+	w := &RBT[T]{cmp: cmp, augment: res.augment, owner: res.owner}
+	x1 := rbtCopy(w, a.root)
+	x2 := rbtCopy(w, b.root)
+	x, _ := rbtSetOp(w, op, x1, rbtBlackHeight(x1), x2, rbtBlackHeight(x2))
+	res.root = x
+	res.size = rbtSize(x)
+	res.mods++
+}
+
+// rbtCopy returns a copy of the subtree rooted at x that belongs to res,
+// storing every value once.
+func rbtCopy[T any](res *RBT[T], x *rbtNode[T]) *rbtNode[T] {
+	if x == nil {
+		return nil
+	}
+	c := &rbtNode[T]{
+		value: x.value,
+		count: 1,
+		color: x.color,
+		owner: res.owner,
+	}
+	c.left = rbtCopy(res, x.left)
+	c.right = rbtCopy(res, x.right)
+	rbtUpdate(res, c)
+	return c
+}
+
+// rbtSetOp applies the operation on the subtrees rooted at x1 and x2, whose
+// black heights are bh1 and bh2, by splitting x2 around the root of x1 and
+// recursing on both sides. Both subtrees are consumed. It returns the root of
+// the resulting subtree together with its black height.
+func rbtSetOp[T any](t *RBT[T], op setOp, x1 *rbtNode[T], bh1 int, x2 *rbtNode[T], bh2 int) (*rbtNode[T], int) {
+	if x1 == nil {
+		if op.keep(false, true) {
+			return x2, bh2
+		}
+		return nil, 0
+	}
+	if x2 == nil {
+		if op.keep(true, false) {
+			return x1, bh1
+		}
+		return nil, 0
+	}
+
+	l1, lbh1, r1, rbh1 := rbtDetach(t, x1, bh1)
+	l2, lbh2, m, r2, rbh2 := rbtSplit(t, x2, bh2, x1.value)
+	l, lbh := rbtSetOp(t, op, l1, lbh1, l2, lbh2)
+	r, rbh := rbtSetOp(t, op, r1, rbh1, r2, rbh2)
+	if op.keep(true, m != nil) {
+		return rbtJoin(t, l, lbh, x1, r, rbh)
+	}
+	return rbtJoin2(t, l, lbh, r, rbh)
+}
@@ -0,0 +1,203 @@
+package tree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// expectedSetOp computes the result of a set operation on sorted slices of
+// distinct values.
+func expectedSetOp(a, b []int, op setOp) []int {
+	res := []int{}
+	for v := range 300 {
+		_, ina := slices.BinarySearch(a, v)
+		_, inb := slices.BinarySearch(b, v)
+		if op.keep(ina, inb) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func TestSetOperations(t *testing.T) {
+	r := rand.New(rand.NewSource(61))
+	ops := map[string]setOp{
+		"union":        opUnion,
+		"intersection": opIntersection,
+		"difference":   opDifference,
+		"symmetric":    opSymmetricDifference,
+	}
+
+	for range 30 {
+		a, b := []int{}, []int{}
+		ta, tb := NewRBT[int](), NewRBT[int]()
+		// the avl trees store the same values, which forces the general
+		// path.
+		aa, ab := NewAVL[int](), NewAVL[int]()
+		for _, v := range r.Perm(300)[:r.Intn(300)] {
+			ta.Insert(v)
+			aa.Insert(v)
+			a = append(a, v)
+		}
+		for _, v := range r.Perm(300)[:r.Intn(300)] {
+			tb.Insert(v)
+			ab.Insert(v)
+			b = append(b, v)
+		}
+		slices.Sort(a)
+		slices.Sort(b)
+
+		for name, op := range ops {
+			expected := expectedSetOp(a, b, op)
+
+			var rres *RBT[int]
+			var bres *BST[int]
+			switch op {
+			case opUnion:
+				rres = Union(ta, tb, NewRBT[int])
+				bres = Union(aa, ab, NewBST[int])
+			case opIntersection:
+				rres = Intersection(ta, tb, NewRBT[int])
+				bres = Intersection(aa, ab, NewBST[int])
+			case opDifference:
+				rres = Difference(ta, tb, NewRBT[int])
+				bres = Difference(aa, ab, NewBST[int])
+			case opSymmetricDifference:
+				rres = SymmetricDifference(ta, tb, NewRBT[int])
+				bres = SymmetricDifference(aa, ab, NewBST[int])
+			}
+
+			if rres.root != nil {
+				checkRBTree(t, rres)
+			}
+			if got := slices.Collect(All(rres)); !slices.Equal(got, expected) {
+				t.Fatalf("%s: expected rbt result %v, got %v", name, expected, got)
+			}
+			if rres.Distinct() != len(expected) {
+				t.Fatalf("%s: expected %d distinct values, got %d", name, len(expected), rres.Distinct())
+			}
+			if got := slices.Collect(All(bres)); !slices.Equal(got, expected) {
+				t.Fatalf("%s: expected bst result %v, got %v", name, expected, got)
+			}
+			// inserting the medians first keeps the bst balanced.
+			if h, maxh := height(bres.Root()), 10; h > maxh {
+				t.Fatalf("%s: expected bst height at most %d, got %d", name, maxh, h)
+			}
+		}
+
+		// the inputs must not be modified.
+		checkRBTree(t, ta)
+		checkRBTree(t, tb)
+		if got := slices.Collect(All(ta)); !slices.Equal(got, a) {
+			t.Fatalf("set operations modified their input")
+		}
+	}
+}
+
+func TestSetOperationsMixed(t *testing.T) {
+	a := NewMultiRBT[int]()
+	b := NewSplayTree[int]()
+	for _, v := range []int{1, 1, 2, 3, 3, 3, 5} {
+		a.Insert(v)
+	}
+	for _, v := range []int{3, 4, 5, 6} {
+		b.Insert(v)
+	}
+
+	if got := slices.Collect(All(Union(a, b, NewMultiRBT[int]))); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("unexpected union %v", got)
+	}
+	if got := slices.Collect(All(Intersection(a, b, NewWBT[int]))); !slices.Equal(got, []int{3, 5}) {
+		t.Errorf("unexpected intersection %v", got)
+	}
+	if got := slices.Collect(All(Difference(b, a, NewLLRB[int]))); !slices.Equal(got, []int{4, 6}) {
+		t.Errorf("unexpected difference %v", got)
+	}
+
+	// the red black fast path with a multiset input.
+	c := NewRBT[int]()
+	c.Insert(3)
+	c.Insert(7)
+	res := SymmetricDifference(a, c, NewRBT[int])
+	checkRBTree(t, res)
+	if got := slices.Collect(All(res)); !slices.Equal(got, []int{1, 2, 5, 7}) {
+		t.Errorf("unexpected symmetric difference %v", got)
+	}
+}
+
+func TestSetOperationsComparator(t *testing.T) {
+	// values are only compared by their tens, so 11 and 12 are the same value
+	// for the set operations.
+	tens := func(a, b int) int { return a/10 - b/10 }
+	a, b := NewRBT[int](), NewRBT[int]()
+	for _, v := range []int{11, 25, 47} {
+		a.Insert(v)
+	}
+	for _, v := range []int{12, 30, 41} {
+		b.Insert(v)
+	}
+
+	for _, tc := range []struct {
+		name     string
+		rres     *RBT[int]
+		bres     *BST[int]
+		expected []int
+	}{
+		{"union", UnionFunc(a, b, NewRBT[int], tens), UnionFunc(a, b, NewBST[int], tens), []int{11, 25, 30, 47}},
+		{"intersection", IntersectionFunc(a, b, NewRBT[int], tens), IntersectionFunc(a, b, NewBST[int], tens), []int{11, 47}},
+		{"difference", DifferenceFunc(a, b, NewRBT[int], tens), DifferenceFunc(a, b, NewBST[int], tens), []int{25}},
+	} {
+		// the red black result is computed by splitting and joining, and the
+		// other one by merging, but both must use the given comparator.
+		if got := slices.Collect(All(tc.rres)); !slices.Equal(got, tc.expected) {
+			t.Errorf("%s: expected %v from red black trees, got %v", tc.name, tc.expected, got)
+		}
+		if got := slices.Collect(All(tc.bres)); !slices.Equal(got, tc.expected) {
+			t.Errorf("%s: expected %v from merging, got %v", tc.name, tc.expected, got)
+		}
+		checkRBTree(t, tc.rres)
+	}
+}
+
+func TestSetComparisons(t *testing.T) {
+	a := NewRBT[int]()
+	b := NewAVL[int]()
+	c := NewMultiBST[int]()
+	for _, v := range []int{1, 2, 3, 4} {
+		a.Insert(v)
+	}
+	for _, v := range []int{4, 3, 2, 1} {
+		b.Insert(v)
+	}
+	for _, v := range []int{2, 2, 3, 8} {
+		c.Insert(v)
+	}
+
+	if Equal[int](a, b) || !EqualValues[int](a, b) {
+		t.Errorf("expected trees with different shapes to have equal values")
+	}
+	if EqualValues[int](a, c) || EqualValues[int](a, NewBST[int]()) {
+		t.Errorf("expected trees with different values not to be equal")
+	}
+	if !EqualValues[int](NewBST[int](), NewRBT[int]()) {
+		t.Errorf("expected empty trees to have equal values")
+	}
+
+	if !IsSubset[int](a, b) || !IsSubset[int](NewBST[int](), a) {
+		t.Errorf("expected subset")
+	}
+	if IsSubset[int](c, a) || IsSubset[int](a, c) {
+		t.Errorf("expected no subset")
+	}
+
+	if IsDisjoint[int](a, c) {
+		t.Errorf("expected trees with common values not to be disjoint")
+	}
+	c.Delete(2)
+	c.Delete(2)
+	c.Delete(3)
+	if !IsDisjoint[int](a, c) || !IsDisjoint[int](a, NewBST[int]()) {
+		t.Errorf("expected disjoint trees")
+	}
+}
@@ -0,0 +1,238 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+)
+
+// SplayTree is a self-adjusting binary search tree. Every access moves the
+// accessed node to the root, which means that reading the tree (e.g. calling
+// Count) also changes its shape.
+type SplayTree[T cmp.Ordered] struct {
+	root *SplayNode[T]
+	size int
+}
+
+// NewSplayTree returns an initialized splay tree.
+func NewSplayTree[T cmp.Ordered]() *SplayTree[T] {
+	return &SplayTree[T]{
+		size: 0,
+		root: nil,
+	}
+}
+
+func (t *SplayTree[T]) Root() Node[T] {
+	panicIfNilTree(t)
+
+	if t.root == nil {
+		return nil
+	}
+	return t.root
+}
+
+func (t *SplayTree[T]) Size() int {
+	panicIfNilTree(t)
+
+	return t.size
+}
+
+// Count splays the node storing value to the root. If value is not found, the
+// last node visited during the search is splayed instead.
+func (t *SplayTree[T]) Count(value T) int {
+	panicIfNilTree(t)
+
+	n, found := splayFind(t, value)
+	if n != nil {
+		splay(t, n)
+	}
+	if !found {
+		return 0
+	}
+	return n.Count()
+}
+
+func (t *SplayTree[T]) Insert(value T) error {
+	panicIfNilTree(t)
+
+	y, found := splayFind(t, value)
+	if found {
+		splay(t, y)
+		return errors.New("value already exists")
+	}
+
+	z := &SplayNode[T]{
+		parent: y,
+		value:  value,
+	}
+	if y == nil {
+		t.root = z
+	} else if value < y.value {
+		y.left = z
+	} else {
+		y.right = z
+	}
+
+	splay(t, z)
+	t.size++
+	return nil
+}
+
+func (t *SplayTree[T]) Delete(value T) error {
+	panicIfNilTree(t)
+
+	z, found := splayFind(t, value)
+	if z != nil {
+		splay(t, z)
+	}
+	if !found {
+		return errors.New("value not found")
+	}
+
+	// z is now the root. Detach both subtrees and join them back together by
+	// splaying the maximum of the left subtree, which leaves it without a right
+	// child.
+	l, r := z.left, z.right
+	if l == nil {
+		t.root = r
+		if r != nil {
+			r.parent = nil
+		}
+	} else {
+		l.parent = nil
+		t.root = l
+		m := l
+		for m.right != nil {
+			m = m.right
+		}
+		splay(t, m)
+		m.right = r
+		if r != nil {
+			r.parent = m
+		}
+	}
+
+	t.size--
+	return nil
+}
+
+func (t *SplayTree[T]) String() string {
+	panicIfNilTree(t)
+
+	return FormatTree(t, string(FormatHorizontal))
+}
+
+type SplayNode[T cmp.Ordered] struct {
+	parent *SplayNode[T]
+	left   *SplayNode[T]
+	right  *SplayNode[T]
+	value  T
+}
+
+func (n *SplayNode[T]) Parent() Node[T] {
+	panicIfNilNode(n)
+
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *SplayNode[T]) Left() Node[T] {
+	panicIfNilNode(n)
+
+	if n.left == nil {
+		return nil
+	}
+	return n.left
+}
+
+func (n *SplayNode[T]) Right() Node[T] {
+	panicIfNilNode(n)
+
+	if n.right == nil {
+		return nil
+	}
+	return n.right
+}
+
+func (n *SplayNode[T]) Value() T {
+	panicIfNilNode(n)
+
+	return n.value
+}
+
+func (n *SplayNode[T]) Count() int {
+	panicIfNilNode(n)
+
+	return 1
+}
+
+// Tree helpers
+
+// splayFind returns the node storing value and true if it exists. Otherwise,
+// it returns the last node visited during the search (nil for an empty tree)
+// and false.
+func splayFind[T cmp.Ordered](t *SplayTree[T], value T) (*SplayNode[T], bool) {
+	var y *SplayNode[T] = nil
+	c := t.root
+	for c != nil {
+		y = c
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return c, true
+		}
+	}
+	return y, false
+}
+
+// splay moves x to the root of the tree using zig, zig-zig and zig-zag steps.
+func splay[T cmp.Ordered](t *SplayTree[T], x *SplayNode[T]) {
+	for x.parent != nil {
+		p := x.parent
+		g := p.parent
+		if g == nil {
+			// zig
+			splayRotate(t, x)
+		} else if (x == p.left) == (p == g.left) {
+			// zig-zig
+			splayRotate(t, p)
+			splayRotate(t, x)
+		} else {
+			// zig-zag
+			splayRotate(t, x)
+			splayRotate(t, x)
+		}
+	}
+}
+
+// splayRotate rotates x above its parent, preserving the binary search tree
+// property.
+func splayRotate[T cmp.Ordered](t *SplayTree[T], x *SplayNode[T]) {
+	p := x.parent
+	g := p.parent
+	if x == p.left {
+		p.left = x.right
+		if x.right != nil {
+			x.right.parent = p
+		}
+		x.right = p
+	} else {
+		p.right = x.left
+		if x.left != nil {
+			x.left.parent = p
+		}
+		x.left = p
+	}
+	p.parent = x
+	x.parent = g
+	if g == nil {
+		t.root = x
+	} else if g.left == p {
+		g.left = x
+	} else {
+		g.right = x
+	}
+}
@@ -0,0 +1,42 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSplayAccessMovesToRoot(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	tr := NewSplayTree[int]()
+
+	for _, v := range r.Perm(300) {
+		tr.Insert(v)
+		if tr.Root().Value() != v {
+			t.Fatalf("expected inserted value %d at the root, got %v", v, tr.Root().Value())
+		}
+		if tr.Root().Parent() != nil {
+			t.Fatalf("root %v has a parent", tr.Root().Value())
+		}
+	}
+	checkParentLinks[int](t, tr.Root())
+
+	for _, v := range r.Perm(300) {
+		if tr.Count(v) != 1 {
+			t.Fatalf("expected %d to be in tree", v)
+		}
+		if tr.Root().Value() != v {
+			t.Fatalf("expected accessed value %d at the root, got %v", v, tr.Root().Value())
+		}
+	}
+	checkParentLinks[int](t, tr.Root())
+
+	for _, v := range r.Perm(300)[:150] {
+		if err := tr.Delete(v); err != nil {
+			t.Fatalf("expected %d to be in tree, got error %s", v, err.Error())
+		}
+		checkParentLinks[int](t, tr.Root())
+	}
+	if tr.Size() != 150 {
+		t.Errorf("expected 150 elements, got %d", tr.Size())
+	}
+}
@@ -0,0 +1,353 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+)
+
+// Split moves the values of the tree into two new trees, returning the one
+// storing the values smaller than key and the one storing the rest. The tree
+// itself is left empty. Split takes O(lg n) time.
+//
+// The two trees don't share any nodes, so they can be used independently, even
+// from different goroutines.
+func (t *RBT[T]) Split(key T) (*RBT[T], *RBT[T]) {
+	panicIfNilTree(t)
+	panicIfUninitialized(t.cmp)
+	cowPrepare(&t.owner)
+
+	// the halves share the owner of the tree, but not its nodes.
+	left := &RBT[T]{cmp: t.cmp, multi: t.multi, augment: t.augment, kind: t.kind, owner: t.owner}
+	right := &RBT[T]{cmp: t.cmp, multi: t.multi, augment: t.augment, kind: t.kind, owner: t.owner}
+	l, _, m, r, rbh := rbtSplit(t, t.root, rbtBlackHeight(t.root), key)
+	if m != nil {
+		r, _ = rbtJoin(t, nil, 0, m, r, rbh)
+	}
+	left.root, right.root = l, r
+	left.size, right.size = rbtSize(l), rbtSize(r)
+
+	t.root = nil
+	t.size = 0
+	t.mods++
+	return left, right
+}
+
+// Join moves pivot and all values of right into the tree, leaving right empty.
+// All values of the tree must be smaller than pivot, and all values of right
+// must be greater than pivot, otherwise an error is returned and neither tree
+// is modified.
+//
+// Both trees must order their values the same way, and either both or neither
+// be multisets, otherwise an error is returned as well. Trees created by NewRBT
+// and NewMultiRBT always order their values the same way. Trees created by
+// NewRBTFunc and NewMultiRBTFunc are only known to do so if they come from the
+// same call, that is if they were split or cloned from the same tree.
+//
+// Join takes O(lg n) time.
+func (t *RBT[T]) Join(pivot T, right *RBT[T]) error {
+	panicIfNilTree(t)
+	panicIfNilTree(right)
+	panicIfUninitialized(t.cmp)
+	panicIfUninitialized(right.cmp)
+
+	if t == right {
+		return errors.New("cannot join a tree with itself")
+	}
+	if t.kind != right.kind || t.multi != right.multi {
+		return errors.New("cannot join trees of different kinds")
+	}
+	if t.root != nil && t.cmp(treeMaximumRbt(t.root).value, pivot) >= 0 {
+		return errors.New("pivot is not greater than all values of the tree")
+	}
+	if right.root != nil && t.cmp(pivot, treeMinimumRbt(right.root).value) >= 0 {
+		return errors.New("pivot is not smaller than all values of right")
+	}
+
+	// the nodes of right are copied as they are modified, unless both trees
+	// have the same owner.
+	cowPrepare(&t.owner)
+	k := &rbtNode[T]{value: pivot, count: 1, owner: t.owner}
+	t.root, _ = rbtJoin(t, t.root, rbtBlackHeight(t.root), k, right.root, rbtBlackHeight(right.root))
+	t.size += right.size + 1
+	t.mods++
+
+	right.root = nil
+	right.size = 0
+	right.mods++
+	return nil
+}
+
+// rbtBlackHeight returns the number of black nodes on any path from x down to
+// a leaf.
+func rbtBlackHeight[T any](x *rbtNode[T]) int {
+	h := 0
+	for ; x != nil; x = x.left {
+		if x.color == _COLOR_BLACK {
+			h++
+		}
+	}
+	return h
+}
+
+// rbtDetach detaches the children of x, whose black height is bh, turning
+// them into valid subtrees with black roots. It returns the children together
+// with their black heights. x must be modifiable by the tree, and so are the
+// children once they are recolored.
+func rbtDetach[T any](t *RBT[T], x *rbtNode[T], bh int) (*rbtNode[T], int, *rbtNode[T], int) {
+	l, r := x.left, x.right
+	lbh, rbh := bh, bh
+	if x.color == _COLOR_BLACK {
+		lbh--
+		rbh--
+	}
+	if rbtIsRed(l) {
+		l = rbtOwn(t, l)
+		l.color = _COLOR_BLACK
+		lbh++
+	}
+	if rbtIsRed(r) {
+		r = rbtOwn(t, r)
+		r.color = _COLOR_BLACK
+		rbh++
+	}
+	return l, lbh, r, rbh
+}
+
+// rbtSplit splits the subtree rooted at x, whose black height is bh, into the
+// subtrees storing the values smaller and greater than key. It returns the
+// roots of both subtrees together with their black heights, and the detached
+// node storing key in between, or nil if there is no such node. The roots are
+// always black.
+func rbtSplit[T any](t *RBT[T], x *rbtNode[T], bh int, key T) (*rbtNode[T], int, *rbtNode[T], *rbtNode[T], int) {
+	if x == nil {
+		return nil, 0, nil, nil, 0
+	}
+
+	x = rbtOwn(t, x)
+	l, lbh, r, rbh := rbtDetach(t, x, bh)
+	if o := t.cmp(key, x.value); o < 0 {
+		ll, llbh, m, lr, lrbh := rbtSplit(t, l, lbh, key)
+		r, rbh = rbtJoin(t, lr, lrbh, x, r, rbh)
+		return ll, llbh, m, r, rbh
+	} else if o > 0 {
+		rl, rlbh, m, rr, rrbh := rbtSplit(t, r, rbh, key)
+		l, lbh = rbtJoin(t, l, lbh, x, rl, rlbh)
+		return l, lbh, m, rr, rrbh
+	}
+	return l, lbh, x, r, rbh
+}
+
+// rbtSplitLast detaches the node storing the largest value from the subtree
+// rooted at x, whose black height is bh. It returns the root of the remaining
+// subtree together with its black height, and the detached node.
+func rbtSplitLast[T any](t *RBT[T], x *rbtNode[T], bh int) (*rbtNode[T], int, *rbtNode[T]) {
+	x = rbtOwn(t, x)
+	l, lbh, r, rbh := rbtDetach(t, x, bh)
+	if r == nil {
+		return l, lbh, x
+	}
+	r, rbh, last := rbtSplitLast(t, r, rbh)
+	l, lbh = rbtJoin(t, l, lbh, x, r, rbh)
+	return l, lbh, last
+}
+
+// rbtJoin joins the subtrees rooted at l and r, whose black heights are lbh
+// and rbh, using k as the node in between. The roots of l and r must be black,
+// and every value of l must be smaller than the value of k, which in turn must
+// be smaller than every value of r. k must be modifiable by the tree. It
+// returns the root of the joined subtree together with its black height.
+//
+// The root of the tree is used as scratch space.
+func rbtJoin[T any](t *RBT[T], l *rbtNode[T], lbh int, k *rbtNode[T], r *rbtNode[T], rbh int) (*rbtNode[T], int) {
+	if lbh == rbh {
+		k.left = l
+		k.right = r
+		k.color = _COLOR_BLACK
+		rbtUpdate(t, k)
+		return k, lbh + 1
+	}
+
+	// find the black node c with the same black height as the shorter subtree
+	// along the inner spine of the taller one, and replace it by k, which
+	// becomes the parent of both c and the shorter subtree. The nodes above c
+	// are modified, so the path to k is made of nodes the tree may modify.
+	path := []*rbtNode[T]{}
+	if lbh > rbh {
+		t.root = l
+		link, h := &t.root, lbh
+		for c := *link; rbtIsRed(c) || h != rbh; c = *link {
+			if !rbtIsRed(c) {
+				h--
+			}
+			c = rbtOwn(t, c)
+			*link = c
+			path = append(path, c)
+			link = &c.right
+		}
+		k.left = *link
+		k.right = r
+		*link = k
+	} else {
+		t.root = r
+		link, h := &t.root, rbh
+		for c := *link; rbtIsRed(c) || h != lbh; c = *link {
+			if !rbtIsRed(c) {
+				h--
+			}
+			c = rbtOwn(t, c)
+			*link = c
+			path = append(path, c)
+			link = &c.left
+		}
+		k.left = l
+		k.right = *link
+		*link = k
+	}
+	k.color = _COLOR_RED
+	path = append(path, k)
+
+	rbtUpdatePath(t, path)
+	// the black height of the taller subtree only grows if the fixup colors
+	// its root black.
+	bh := max(lbh, rbh)
+	if insertFixup(t, path) {
+		bh++
+	}
+	return t.root, bh
+}
+
+// rbtJoin2 is like rbtJoin, but without a node in between.
+func rbtJoin2[T any](t *RBT[T], l *rbtNode[T], lbh int, r *rbtNode[T], rbh int) (*rbtNode[T], int) {
+	if l == nil {
+		return r, rbh
+	}
+	if r == nil {
+		return l, lbh
+	}
+	l, lbh, k := rbtSplitLast(t, l, lbh)
+	return rbtJoin(t, l, lbh, k, r, rbh)
+}
+
+// Split moves the values of the tree into two new trees, returning the one
+// storing the values smaller than key and the one storing the rest. The tree
+// itself is left empty. Split takes O(lg n) time.
+func (t *AVLTree[T]) Split(key T) (*AVLTree[T], *AVLTree[T]) {
+	panicIfNilTree(t)
+
+	l, r := avlSplit(t, t.root, key)
+	left := &AVLTree[T]{root: l, size: avlSize(l)}
+	right := &AVLTree[T]{root: r, size: avlSize(r)}
+
+	t.root = nil
+	t.size = 0
+	return left, right
+}
+
+// Join moves pivot and all values of right into the tree, leaving right empty.
+// All values of the tree must be smaller than pivot, and all values of right
+// must be greater than pivot, otherwise an error is returned and neither tree
+// is modified. Join takes O(lg n) time.
+func (t *AVLTree[T]) Join(pivot T, right *AVLTree[T]) error {
+	panicIfNilTree(t)
+	panicIfNilTree(right)
+
+	if t == right {
+		return errors.New("cannot join a tree with itself")
+	}
+	if t.root != nil && treeMaximumAvl(t.root).value >= pivot {
+		return errors.New("pivot is not greater than all values of the tree")
+	}
+	if right.root != nil && pivot >= treeMinimumAvl(right.root).value {
+		return errors.New("pivot is not smaller than all values of right")
+	}
+
+	k := &AVLNode[T]{value: pivot}
+	t.root = avlJoin(t, t.root, k, right.root)
+	t.size += right.size + 1
+
+	right.root = nil
+	right.size = 0
+	return nil
+}
+
+// avlSplit splits the subtree rooted at x into the subtrees storing the values
+// smaller than key and the rest, and returns their roots.
+func avlSplit[T cmp.Ordered](t *AVLTree[T], x *AVLNode[T], key T) (*AVLNode[T], *AVLNode[T]) {
+	if x == nil {
+		return nil, nil
+	}
+
+	l, r := x.left, x.right
+	if key <= x.value {
+		ll, lr := avlSplit(t, l, key)
+		return ll, avlJoin(t, lr, x, r)
+	}
+	rl, rr := avlSplit(t, r, key)
+	return avlJoin(t, l, x, rl), rr
+}
+
+// avlJoin joins the subtrees rooted at l and r using k as the node in between,
+// and returns the root of the joined subtree. Every value of l must be smaller
+// than the value of k, which in turn must be smaller than every value of r.
+//
+// The root of the tree is used as scratch space.
+func avlJoin[T cmp.Ordered](t *AVLTree[T], l *AVLNode[T], k *AVLNode[T], r *AVLNode[T]) *AVLNode[T] {
+	lh, rh := avlHeight(l), avlHeight(r)
+	if lh-rh <= 1 && rh-lh <= 1 {
+		k.parent = nil
+		k.left = l
+		k.right = r
+		if l != nil {
+			l.parent = k
+		}
+		if r != nil {
+			r.parent = k
+		}
+		avlUpdate(k)
+		return k
+	}
+
+	// find the first node c along the inner spine of the taller subtree that
+	// is at most one level taller than the shorter one, and replace it by k,
+	// which becomes the parent of both c and the shorter subtree.
+	var p *AVLNode[T]
+	if lh > rh {
+		t.root = l
+		c := l
+		for avlHeight(c) > rh+1 {
+			p = c
+			c = c.right
+		}
+		p.right = k
+		k.left = c
+		k.right = r
+	} else {
+		t.root = r
+		c := r
+		for avlHeight(c) > lh+1 {
+			p = c
+			c = c.left
+		}
+		p.left = k
+		k.left = l
+		k.right = c
+	}
+	k.parent = p
+	if k.left != nil {
+		k.left.parent = k
+	}
+	if k.right != nil {
+		k.right.parent = k
+	}
+	t.root.parent = nil
+
+	avlRebalance(t, k)
+	return t.root
+}
+
+func treeMaximumAvl[T cmp.Ordered](x *AVLNode[T]) *AVLNode[T] {
+	for x.right != nil {
+		x = x.right
+	}
+	return x
+}
@@ -0,0 +1,323 @@
+package tree
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// checkRBT verifies sizes and the red black properties of the subtree rooted
+// at n, returning its black height.
+func checkRBT(t *testing.T, tr *RBT[int], n *rbtNode[int]) int {
+	t.Helper()
+
+	if n == nil {
+		return 0
+	}
+	if rbtIsRed(n) && (rbtIsRed(n.left) || rbtIsRed(n.right)) {
+		t.Fatalf("red node %d has a red child", n.value)
+	}
+	lbh := checkRBT(t, tr, n.left)
+	rbh := checkRBT(t, tr, n.right)
+	if lbh != rbh {
+		t.Fatalf("node %d has black heights %d and %d", n.value, lbh, rbh)
+	}
+	if size := rbtSize(n.left) + rbtSize(n.right) + n.count; n.size != size {
+		t.Fatalf("node %d stores size %d, expected %d", n.value, n.size, size)
+	}
+	if distinct := rbtDistinct(n.left) + rbtDistinct(n.right) + 1; n.distinct != distinct {
+		t.Fatalf("node %d stores %d distinct values, expected %d", n.value, n.distinct, distinct)
+	}
+	if n.color == _COLOR_BLACK {
+		return lbh + 1
+	}
+	return lbh
+}
+
+// checkRBTree verifies the whole tree, including its root and size.
+func checkRBTree(t *testing.T, tr *RBT[int]) {
+	t.Helper()
+
+	if rbtIsRed(tr.root) {
+		t.Fatalf("invalid root")
+	}
+	checkRBT(t, tr, tr.root)
+	if rbtSize(tr.root) != tr.Size() {
+		t.Fatalf("root size %d differs from tree size %d", rbtSize(tr.root), tr.Size())
+	}
+}
+
+func TestRBTSplitJoin(t *testing.T) {
+	r := rand.New(rand.NewSource(53))
+
+	for range 50 {
+		tr := NewRBT[int]()
+		values := []int{}
+		for _, v := range r.Perm(500)[:r.Intn(500)] {
+			tr.Insert(v)
+			values = append(values, v)
+		}
+		slices.Sort(values)
+
+		key := r.Intn(520) - 10
+		left, right := tr.Split(key)
+		checkRBTree(t, left)
+		checkRBTree(t, right)
+		if tr.Size() != 0 || tr.Root() != nil {
+			t.Fatalf("expected split tree to be empty, got size %d", tr.Size())
+		}
+
+		i, _ := slices.BinarySearch(values, key)
+		if got := slices.Collect(All(left)); !slices.Equal(got, values[:i]) {
+			t.Fatalf("split at %d: expected left %v, got %v", key, values[:i], got)
+		}
+		if got := slices.Collect(All(right)); !slices.Equal(got, values[i:]) {
+			t.Fatalf("split at %d: expected right %v, got %v", key, values[i:], got)
+		}
+		if left.Distinct() != i || right.Distinct() != len(values)-i {
+			t.Fatalf("split at %d: unexpected distinct counts %d and %d", key, left.Distinct(), right.Distinct())
+		}
+
+		// join the halves back together around a value between them.
+		pivot := 1000
+		if right.Size() != 0 {
+			pivot = Min(right).Value()
+			right.Delete(pivot)
+		}
+		if err := left.Join(pivot, right); err != nil {
+			t.Fatalf("expected join to succeed, got error %s", err.Error())
+		}
+		checkRBTree(t, left)
+		if right.Size() != 0 || right.Root() != nil {
+			t.Fatalf("expected joined tree to be empty, got size %d", right.Size())
+		}
+		if pivot == 1000 {
+			left.Delete(pivot)
+		}
+		if got := slices.Collect(All(left)); !slices.Equal(got, values) {
+			t.Fatalf("expected joined tree %v, got %v", values, got)
+		}
+
+		// the joined tree must still be usable.
+		for range 100 {
+			v := r.Intn(500)
+			if r.Intn(2) == 0 {
+				left.Delete(v)
+			} else {
+				left.Insert(v)
+			}
+			checkRBTree(t, left)
+		}
+	}
+}
+
+func TestRBTJoinSeparateTrees(t *testing.T) {
+	for _, sizes := range [][2]int{{0, 0}, {0, 10}, {10, 0}, {1, 300}, {300, 1}, {50, 60}} {
+		left, right := NewRBT[int](), NewRBT[int]()
+		expected := []int{}
+		for v := range sizes[0] {
+			left.Insert(v)
+			expected = append(expected, v)
+		}
+		expected = append(expected, 1000)
+		for v := range sizes[1] {
+			right.Insert(2000 + v)
+			expected = append(expected, 2000+v)
+		}
+
+		if err := left.Join(1000, right); err != nil {
+			t.Fatalf("expected join to succeed, got error %s", err.Error())
+		}
+		checkRBTree(t, left)
+		if got := slices.Collect(All(left)); !slices.Equal(got, expected) {
+			t.Fatalf("expected joined tree %v, got %v", expected, got)
+		}
+
+		// right must be usable on its own afterwards.
+		right.Insert(5)
+		if left.Size() != len(expected) || right.Size() != 1 {
+			t.Fatalf("insertion into emptied tree changed the joined tree")
+		}
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	left, right := NewRBT[int](), NewRBT[int]()
+	left.Insert(5)
+	right.Insert(10)
+
+	for _, pivot := range []int{3, 5, 10, 12} {
+		if err := left.Join(pivot, right); err == nil {
+			t.Errorf("expected joining around %d to fail", pivot)
+		}
+	}
+	if err := left.Join(7, left); err == nil {
+		t.Errorf("expected joining a tree with itself to fail")
+	}
+	if left.Size() != 1 || right.Size() != 1 {
+		t.Errorf("failed joins modified the trees")
+	}
+
+	reverse := func(a, b int) int { return b - a }
+	for name, other := range map[string]*RBT[int]{
+		"multiset":    NewMultiRBT[int](),
+		"comparator":  NewRBTFunc(reverse),
+		"constructor": NewRBTFunc(func(a, b int) int { return a - b }),
+	} {
+		other.Insert(10)
+		if err := left.Join(7, other); err == nil {
+			t.Errorf("expected joining a tree with a different %s to fail", name)
+		}
+	}
+
+	// trees created by separate calls to NewRBTFunc may order values
+	// differently, unless they come from the same tree.
+	rleft, rother := NewRBTFunc(reverse), NewRBTFunc(reverse)
+	rleft.Insert(10)
+	rother.Insert(5)
+	if err := rleft.Join(7, rother); err == nil {
+		t.Errorf("expected trees created by separate calls to fail to be joined")
+	}
+	rright := rleft.Clone()
+	rright.Delete(10)
+	rright.Insert(5)
+	if err := rleft.Join(7, rright); err != nil {
+		t.Errorf("expected a tree to be joined with its clone, got error %s", err.Error())
+	}
+	mleft, mright := NewMultiRBT[int](), NewMultiRBT[int]()
+	mleft.Insert(5)
+	mright.Insert(10)
+	if err := mleft.Join(7, mright); err != nil {
+		t.Errorf("expected multisets created by NewMultiRBT to be joined, got error %s", err.Error())
+	}
+
+	aleft, aright := NewAVL[int](), NewAVL[int]()
+	aleft.Insert(5)
+	aright.Insert(10)
+	for _, pivot := range []int{3, 5, 10, 12} {
+		if err := aleft.Join(pivot, aright); err == nil {
+			t.Errorf("expected joining around %d to fail", pivot)
+		}
+	}
+}
+
+func TestRBTSplitMultiset(t *testing.T) {
+	tr := NewMultiRBT[int]()
+	// sizes of the two halves when splitting at 40.
+	lsize, rsize := 0, 0
+	for v := range 100 {
+		for range v%3 + 1 {
+			tr.Insert(v)
+			if v < 40 {
+				lsize++
+			} else {
+				rsize++
+			}
+		}
+	}
+
+	left, right := tr.Split(40)
+	checkRBTree(t, left)
+	checkRBTree(t, right)
+	if left.Count(39) != 1 || left.Count(40) != 0 || right.Count(40) != 2 {
+		t.Errorf("unexpected counts after split")
+	}
+	if left.Size() != lsize || right.Size() != rsize {
+		t.Errorf("expected sizes %d and %d, got %d and %d", lsize, rsize, left.Size(), right.Size())
+	}
+	if left.Distinct() != 40 || right.Distinct() != 60 {
+		t.Errorf("expected 40 and 60 distinct values, got %d and %d", left.Distinct(), right.Distinct())
+	}
+
+	if err := left.Join(40, right); err == nil {
+		t.Fatalf("expected join around a stored value to fail")
+	}
+	right.Delete(40)
+	right.Delete(40)
+	if err := left.Join(40, right); err != nil {
+		t.Fatalf("expected join to succeed, got error %s", err.Error())
+	}
+	checkRBTree(t, left)
+	if left.Distinct() != 100 || left.Size() != lsize+rsize-1 {
+		t.Errorf("expected 100 distinct values and size %d, got %d and %d", lsize+rsize-1, left.Distinct(), left.Size())
+	}
+}
+
+func TestRBTSplitIndependentHalves(t *testing.T) {
+	tr := NewRBT[int]()
+	for v := range 1000 {
+		tr.Insert(v)
+	}
+	left, right := tr.Split(500)
+
+	// the halves don't share any nodes, so they can be used from different
+	// goroutines.
+	var wg sync.WaitGroup
+	for i, half := range []*RBT[int]{left, right} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range 500 {
+				half.Delete(i*500 + v)
+				half.Insert(2000 + i*1000 + v)
+			}
+		}()
+	}
+	wg.Wait()
+
+	checkRBTree(t, left)
+	checkRBTree(t, right)
+	if left.Size() != 500 || right.Size() != 500 || Min(right).Value() != 3000 {
+		t.Errorf("unexpected halves after concurrent modifications")
+	}
+}
+
+func TestAVLSplitJoin(t *testing.T) {
+	r := rand.New(rand.NewSource(59))
+
+	for range 50 {
+		tr := NewAVL[int]()
+		values := []int{}
+		for _, v := range r.Perm(500)[:r.Intn(500)] {
+			tr.Insert(v)
+			values = append(values, v)
+		}
+		slices.Sort(values)
+
+		key := r.Intn(520) - 10
+		left, right := tr.Split(key)
+		checkAVL(t, left.root)
+		checkAVL(t, right.root)
+		if tr.Size() != 0 || tr.Root() != nil {
+			t.Fatalf("expected split tree to be empty, got size %d", tr.Size())
+		}
+
+		i, _ := slices.BinarySearch(values, key)
+		if got := slices.Collect(All(left)); !slices.Equal(got, values[:i]) || left.Size() != i {
+			t.Fatalf("split at %d: expected left %v, got %v", key, values[:i], got)
+		}
+		if got := slices.Collect(All(right)); !slices.Equal(got, values[i:]) || right.Size() != len(values)-i {
+			t.Fatalf("split at %d: expected right %v, got %v", key, values[i:], got)
+		}
+
+		if err := left.Join(key, right); err != nil {
+			if right.Count(key) == 0 {
+				t.Fatalf("expected join to succeed, got error %s", err.Error())
+			}
+			right.Delete(key)
+			left.Join(key, right)
+		}
+		checkAVL(t, left.root)
+		if left.root != nil && left.root.parent != nil {
+			t.Fatalf("joined root has a parent")
+		}
+		expected := slices.Clone(values)
+		if _, found := slices.BinarySearch(values, key); !found {
+			expected = slices.Insert(expected, i, key)
+		}
+		if got := slices.Collect(All(left)); !slices.Equal(got, expected) || left.Size() != len(expected) {
+			t.Fatalf("expected joined tree %v, got %v", expected, got)
+		}
+	}
+}
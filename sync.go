@@ -0,0 +1,377 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+	"sync"
+)
+
+// SyncTree wraps a tree, making it safe for concurrent use by multiple
+// goroutines. Insert and Delete take an exclusive lock, while Size and Count
+// take a shared one, except for trees that change their shape on reads (such
+// as splay trees), which always take an exclusive lock.
+//
+// Nodes of the wrapped tree are not guarded by the lock once Root returns. Use
+// View to run several reads on the tree itself, or Snapshot to get a
+// consistent copy that can be read while writers proceed.
+type SyncTree[T any] struct {
+	mu sync.RWMutex
+	t  Tree[T]
+	// exclusive is set for trees that modify themselves on reads.
+	exclusive bool
+	// cmp orders the values of the wrapped tree, if known, which allows
+	// searching snapshots that are not clones.
+	cmp func(a, b T) int
+}
+
+// cloner is implemented by trees that can be copied in O(1) time.
+type cloner[T any] interface {
+	cloneTree() Tree[T]
+}
+
+// selfAdjusting is implemented by trees whose shape changes on reads.
+type selfAdjusting interface {
+	adjustsOnRead()
+}
+
+// comparer is implemented by trees that can compare values the same way they
+// order them.
+type comparer[T any] interface {
+	compare(a, b T) int
+}
+
+func (t *BST[T]) cloneTree() Tree[T] {
+	return t.Clone()
+}
+
+func (t *RBT[T]) cloneTree() Tree[T] {
+	return t.Clone()
+}
+
+func (t *Augmented[T, A]) cloneTree() Tree[T] {
+	return &Augmented[T, A]{
+		t:   t.t.Clone(),
+		agg: t.agg,
+		cmp: t.cmp,
+	}
+}
+
+func (t *SplayTree[T]) adjustsOnRead() {}
+
+func (t *AVLTree[T]) compare(a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+func (t *AATree[T]) compare(a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+func (t *LLRB[T]) compare(a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+func (t *Scapegoat[T]) compare(a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+func (t *SplayTree[T]) compare(a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+func (t *Treap[T]) compare(a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+func (t *WBT[T]) compare(a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+// Synchronized returns a wrapper of t that is safe for concurrent use. The
+// wrapped tree must not be used directly afterwards.
+//
+// Snapshots of trees that are not implemented by this package can only be
+// searched if the wrapper is created with SynchronizedFunc.
+func Synchronized[T any](t Tree[T]) *SyncTree[T] {
+	panicIfNilTree(t)
+
+	var cmp func(a, b T) int
+	if c, ok := t.(comparer[T]); ok {
+		cmp = c.compare
+	}
+	return SynchronizedFunc(t, cmp)
+}
+
+// SynchronizedFunc is like Synchronized, but snapshots compare values using
+// cmp, which must order values the same way the tree does.
+func SynchronizedFunc[T any](t Tree[T], cmp func(a, b T) int) *SyncTree[T] {
+	panicIfNilTree(t)
+
+	_, exclusive := t.(selfAdjusting)
+	return &SyncTree[T]{
+		t:         t,
+		exclusive: exclusive,
+		cmp:       cmp,
+	}
+}
+
+func (s *SyncTree[T]) rlock() {
+	if s.exclusive {
+		s.mu.Lock()
+	} else {
+		s.mu.RLock()
+	}
+}
+
+func (s *SyncTree[T]) runlock() {
+	if s.exclusive {
+		s.mu.Unlock()
+	} else {
+		s.mu.RUnlock()
+	}
+}
+
+// Root returns the root of the wrapped tree, read while holding a shared lock.
+// Its nodes are not guarded by the lock, so they may only be read while no
+// goroutine modifies the tree, which includes calling functions such as
+// FormatTree or Min on the wrapper.
+func (s *SyncTree[T]) Root() Node[T] {
+	panicIfNilTree(s)
+
+	var root Node[T]
+	s.View(func(t Tree[T]) {
+		root = t.Root()
+	})
+	return root
+}
+
+func (s *SyncTree[T]) Size() int {
+	panicIfNilTree(s)
+
+	s.rlock()
+	defer s.runlock()
+	return s.t.Size()
+}
+
+func (s *SyncTree[T]) Count(value T) int {
+	panicIfNilTree(s)
+
+	s.rlock()
+	defer s.runlock()
+	return s.t.Count(value)
+}
+
+func (s *SyncTree[T]) Insert(value T) error {
+	panicIfNilTree(s)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.t.Insert(value)
+}
+
+func (s *SyncTree[T]) Delete(value T) error {
+	panicIfNilTree(s)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.t.Delete(value)
+}
+
+func (s *SyncTree[T]) String() string {
+	panicIfNilTree(s)
+
+	s.rlock()
+	defer s.runlock()
+	return FormatTree(s.t, string(FormatHorizontal))
+}
+
+// View calls fn with the wrapped tree while holding a shared lock, so that fn
+// can read the tree, e.g. by iterating over it, without copying it. fn must
+// not modify the tree or retain it after returning.
+func (s *SyncTree[T]) View(fn func(t Tree[T])) {
+	panicIfNilTree(s)
+
+	s.rlock()
+	defer s.runlock()
+	fn(s.t)
+}
+
+// Snapshot returns a copy of the tree, which is unaffected by later
+// modifications and can be read without holding any lock.
+//
+// BST, RBT and Augmented trees are cloned in O(1) time, see RBT.Clone, and the
+// snapshot belongs to the caller. Other trees are copied in O(n) time into a
+// read-only tree with the same shape, whose Insert and Delete always fail. Its
+// Count panics if the wrapper doesn't know how values are ordered, see
+// Synchronized.
+func (s *SyncTree[T]) Snapshot() Tree[T] {
+	panicIfNilTree(s)
+
+	s.rlock()
+	defer s.runlock()
+	if c, ok := s.t.(cloner[T]); ok {
+		return c.cloneTree()
+	}
+	return freeze(s.t, s.cmp)
+}
+
+// frozenTree is a read-only copy of a tree.
+type frozenTree[T any] struct {
+	root *frozenNode[T]
+	size int
+	// cmp orders the values of the tree, or is nil if unknown.
+	cmp func(a, b T) int
+}
+
+type frozenNode[T any] struct {
+	parent *frozenNode[T]
+	left   *frozenNode[T]
+	right  *frozenNode[T]
+	value  T
+	count  int
+	// size is the number of elements stored in the subtree rooted at this
+	// node, counting duplicates.
+	size int
+}
+
+// freeze returns a read-only copy of t with the same shape, whose values are
+// ordered by cmp.
+func freeze[T any](t Tree[T], cmp func(a, b T) int) *frozenTree[T] {
+	f := &frozenTree[T]{size: t.Size(), cmp: cmp}
+	root := t.Root()
+	if root == nil {
+		return f
+	}
+
+	type stkobj struct {
+		n Node[T]
+		c *frozenNode[T]
+	}
+
+	f.root = &frozenNode[T]{value: root.Value(), count: root.Count()}
+	// copies in pre-order, so that children can be visited before their
+	// parents by going backwards.
+	copies := []*frozenNode[T]{}
+	stack := []stkobj{{n: root, c: f.root}}
+	for len(stack) != 0 {
+		cobj := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		copies = append(copies, cobj.c)
+		if l := cobj.n.Left(); l != nil {
+			cobj.c.left = &frozenNode[T]{parent: cobj.c, value: l.Value(), count: l.Count()}
+			stack = append(stack, stkobj{n: l, c: cobj.c.left})
+		}
+		if r := cobj.n.Right(); r != nil {
+			cobj.c.right = &frozenNode[T]{parent: cobj.c, value: r.Value(), count: r.Count()}
+			stack = append(stack, stkobj{n: r, c: cobj.c.right})
+		}
+	}
+	for i := len(copies) - 1; i >= 0; i-- {
+		c := copies[i]
+		c.size = c.count
+		if c.left != nil {
+			c.size += c.left.size
+		}
+		if c.right != nil {
+			c.size += c.right.size
+		}
+	}
+	return f
+}
+
+func (t *frozenTree[T]) Root() Node[T] {
+	panicIfNilTree(t)
+
+	if t.root == nil {
+		return nil
+	}
+	return t.root
+}
+
+func (t *frozenTree[T]) Size() int {
+	panicIfNilTree(t)
+
+	return t.size
+}
+
+// Count panics if the ordering of the original tree is not known.
+func (t *frozenTree[T]) Count(value T) int {
+	panicIfNilTree(t)
+
+	if t.cmp == nil {
+		panic("snapshot doesn't know how values are ordered")
+	}
+	c := t.root
+	for c != nil {
+		if o := t.cmp(value, c.value); o < 0 {
+			c = c.left
+		} else if o > 0 {
+			c = c.right
+		} else {
+			return c.count
+		}
+	}
+	return 0
+}
+
+func (t *frozenTree[T]) Insert(value T) error {
+	panicIfNilTree(t)
+
+	return errors.New("snapshot is read-only")
+}
+
+func (t *frozenTree[T]) Delete(value T) error {
+	panicIfNilTree(t)
+
+	return errors.New("snapshot is read-only")
+}
+
+func (t *frozenTree[T]) String() string {
+	panicIfNilTree(t)
+
+	return FormatTree[T](t, string(FormatHorizontal))
+}
+
+func (n *frozenNode[T]) Parent() Node[T] {
+	panicIfNilNode(n)
+
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *frozenNode[T]) Left() Node[T] {
+	panicIfNilNode(n)
+
+	if n.left == nil {
+		return nil
+	}
+	return n.left
+}
+
+func (n *frozenNode[T]) Right() Node[T] {
+	panicIfNilNode(n)
+
+	if n.right == nil {
+		return nil
+	}
+	return n.right
+}
+
+func (n *frozenNode[T]) Value() T {
+	panicIfNilNode(n)
+
+	return n.value
+}
+
+func (n *frozenNode[T]) Count() int {
+	panicIfNilNode(n)
+
+	return n.count
+}
+
+func (n *frozenNode[T]) SubtreeSize() int {
+	panicIfNilNode(n)
+
+	return n.size
+}
@@ -0,0 +1,155 @@
+package tree
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// These tests are meant to be run with the race detector enabled, i.e.
+// go test -race.
+
+func TestSynchronized(t *testing.T) {
+	trees := implementations()
+	trees["augmented"] = NewAugmented(SumAggregator[int]())
+
+	for name, tr := range trees {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := Synchronized(tr)
+			for v := range 100 {
+				s.Insert(v)
+			}
+
+			wg := sync.WaitGroup{}
+			for w := range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					r := rand.New(rand.NewSource(int64(w)))
+					for range 300 {
+						// values below 100 are never deleted.
+						v := 100 + r.Intn(100)
+						if r.Intn(2) == 0 {
+							s.Insert(v)
+						} else {
+							s.Delete(v)
+						}
+					}
+				}()
+			}
+			for range 4 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 50 {
+						if s.Count(50) != 1 || s.Size() < 100 {
+							t.Errorf("lost values stored before writers started")
+							return
+						}
+						s.View(func(t Tree[int]) {
+							for range All(t) {
+							}
+						})
+
+						snap := s.Snapshot()
+						values := slices.Collect(All(snap))
+						if len(values) != snap.Size() || !slices.IsSorted(values) {
+							t.Errorf("inconsistent snapshot %v with size %d", values, snap.Size())
+							return
+						}
+						if len(values) != 0 && Select(snap, len(values)-1).Value() != values[len(values)-1] {
+							t.Errorf("inconsistent snapshot order statistics")
+							return
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			checkParentLinks(t, s.Root())
+		})
+	}
+}
+
+func TestSnapshotIsolation(t *testing.T) {
+	for name, tr := range map[string]Tree[int]{"rbt": NewRBT[int](), "avl": NewAVL[int]()} {
+		s := Synchronized(tr)
+		for v := range 10 {
+			s.Insert(v)
+		}
+		snap := s.Snapshot()
+		s.Insert(10)
+		s.Delete(0)
+
+		if got := slices.Collect(All(snap)); !slices.Equal(got, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+			t.Errorf("%s: expected snapshot to be unaffected by later writes, got %v", name, got)
+		}
+		if snap.Count(0) != 1 || snap.Count(10) != 0 {
+			t.Errorf("%s: unexpected counts in snapshot", name)
+		}
+		if got := slices.Collect(All[int](s)); !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}) {
+			t.Errorf("%s: expected writes to be visible in the tree, got %v", name, got)
+		}
+	}
+
+	frozen := Synchronized[int](NewAVL[int]()).Snapshot()
+	if frozen.Insert(1) == nil || frozen.Size() != 0 || frozen.Root() != nil {
+		t.Errorf("expected snapshots of avl trees to be read-only")
+	}
+}
+
+func TestSyncTreeRoot(t *testing.T) {
+	for name, tr := range map[string]Tree[int]{"rbt": NewRBT[int](), "avl": NewAVL[int]()} {
+		s := Synchronized(tr)
+		for v := range 10 {
+			s.Insert(v)
+		}
+		// the root is read from the tree itself, without copying it.
+		if !sameNode(s.Root(), tr.Root()) || !sameNode(s.Root(), s.Root()) {
+			t.Errorf("%s: expected root of the wrapped tree", name)
+		}
+		if Min[int](s).Value() != 0 || Max[int](s).Value() != 9 {
+			t.Errorf("%s: unexpected extremes when reading through the wrapper", name)
+		}
+	}
+}
+
+// opaqueTree hides the internal interfaces of the tree it embeds, like trees
+// implemented outside this package.
+type opaqueTree[T any] struct {
+	Tree[T]
+}
+
+func TestSnapshotComparator(t *testing.T) {
+	type tagged struct {
+		key  int
+		tags []string
+	}
+	// tagged values are not comparable with ==, and are ordered in reverse.
+	byKey := func(a, b tagged) int { return b.key - a.key }
+
+	s := SynchronizedFunc[tagged](opaqueTree[tagged]{NewBSTFunc(byKey)}, byKey)
+	for v := range 20 {
+		s.Insert(tagged{key: v})
+	}
+	snap := s.Snapshot()
+	if _, ok := snap.(*frozenTree[tagged]); !ok {
+		t.Fatalf("expected snapshot to be a frozen copy")
+	}
+	for v := range 20 {
+		if snap.Count(tagged{key: v}) != 1 {
+			t.Errorf("expected snapshot to store %d", v)
+		}
+	}
+	if snap.Count(tagged{key: 20}) != 0 {
+		t.Errorf("expected snapshot not to store 20")
+	}
+
+	unknown := Synchronized[tagged](opaqueTree[tagged]{NewBSTFunc(byKey)}).Snapshot()
+	if !expectPanic(func() { unknown.Count(tagged{key: 1}) }) {
+		t.Errorf("expected searching a snapshot of an unknown ordering to panic")
+	}
+}
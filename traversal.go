@@ -0,0 +1,115 @@
+package tree
+
+import "iter"
+
+// The traversals below visit the subtree rooted at a node, yielding every node
+// together with its depth relative to that node (which has depth 0). A nil node
+// is treated as an empty subtree, so it is safe to pass the result of
+// Tree.Root() directly.
+//
+// All traversals use explicit stacks or queues instead of recursion, so they
+// also work on degenerate trees. The tree must not be modified during
+// traversal.
+
+// PreOrder returns an iterator visiting every node before its children, with
+// the left subtree visited before the right one.
+func PreOrder[T any](n Node[T]) iter.Seq2[int, Node[T]] {
+	return func(yield func(int, Node[T]) bool) {
+		if n == nil {
+			return
+		}
+
+		type stkobj struct {
+			n     Node[T]
+			depth int
+		}
+
+		stack := []stkobj{{n: n, depth: 0}}
+		for len(stack) != 0 {
+			cobj := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(cobj.depth, cobj.n) {
+				return
+			}
+			// push the right child first, so that the left one is popped
+			// first.
+			if r := cobj.n.Right(); r != nil {
+				stack = append(stack, stkobj{n: r, depth: cobj.depth + 1})
+			}
+			if l := cobj.n.Left(); l != nil {
+				stack = append(stack, stkobj{n: l, depth: cobj.depth + 1})
+			}
+		}
+	}
+}
+
+// PostOrder returns an iterator visiting every node after its children, with
+// the left subtree visited before the right one.
+func PostOrder[T any](n Node[T]) iter.Seq2[int, Node[T]] {
+	return func(yield func(int, Node[T]) bool) {
+		if n == nil {
+			return
+		}
+
+		type stkobj struct {
+			n     Node[T]
+			depth int
+			// cnt is the number of children that were already pushed.
+			cnt int
+		}
+
+		stack := []*stkobj{{n: n, depth: 0, cnt: 0}}
+		for len(stack) != 0 {
+			cobj := stack[len(stack)-1]
+
+			var next Node[T]
+			if cobj.cnt == 0 {
+				next = cobj.n.Left()
+			} else if cobj.cnt == 1 {
+				next = cobj.n.Right()
+			} else {
+				// processed both left and right
+				stack = stack[:len(stack)-1]
+				if !yield(cobj.depth, cobj.n) {
+					return
+				}
+				continue
+			}
+
+			cobj.cnt += 1
+			if next != nil {
+				stack = append(stack, &stkobj{n: next, depth: cobj.depth + 1, cnt: 0})
+			}
+		}
+	}
+}
+
+// LevelOrder returns an iterator visiting the nodes in breadth first order,
+// level by level from left to right.
+func LevelOrder[T any](n Node[T]) iter.Seq2[int, Node[T]] {
+	return func(yield func(int, Node[T]) bool) {
+		if n == nil {
+			return
+		}
+
+		type qobj struct {
+			n     Node[T]
+			depth int
+		}
+
+		queue := []qobj{{n: n, depth: 0}}
+		for len(queue) != 0 {
+			cobj := queue[0]
+			queue = queue[1:]
+			if !yield(cobj.depth, cobj.n) {
+				return
+			}
+			if l := cobj.n.Left(); l != nil {
+				queue = append(queue, qobj{n: l, depth: cobj.depth + 1})
+			}
+			if r := cobj.n.Right(); r != nil {
+				queue = append(queue, qobj{n: r, depth: cobj.depth + 1})
+			}
+		}
+	}
+}
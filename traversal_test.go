@@ -0,0 +1,91 @@
+package tree
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestTraversals(t *testing.T) {
+	//       4
+	//      / \
+	//     2   12
+	//    /   / \
+	//   1   8   13
+	//      / \
+	//     6   9
+	tr := NewBST[int]()
+	for _, v := range []int{4, 2, 12, 1, 8, 13, 6, 9} {
+		tr.Insert(v)
+	}
+
+	type testcase struct {
+		name     string
+		seq      iter.Seq2[int, Node[int]]
+		expected []string
+	}
+
+	testcases := []testcase{
+		{
+			name:     "preorder",
+			seq:      PreOrder(tr.Root()),
+			expected: []string{"0:4", "1:2", "2:1", "1:12", "2:8", "3:6", "3:9", "2:13"},
+		},
+		{
+			name:     "postorder",
+			seq:      PostOrder(tr.Root()),
+			expected: []string{"2:1", "1:2", "3:6", "3:9", "2:8", "2:13", "1:12", "0:4"},
+		},
+		{
+			name:     "levelorder",
+			seq:      LevelOrder(tr.Root()),
+			expected: []string{"0:4", "1:2", "1:12", "2:1", "2:8", "2:13", "3:6", "3:9"},
+		},
+		{
+			name:     "subtree",
+			seq:      PreOrder(tr.Root().Right().Left()),
+			expected: []string{"0:8", "1:6", "1:9"},
+		},
+		{
+			name:     "nil",
+			seq:      LevelOrder[int](nil),
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := []string{}
+			for depth, n := range tc.seq {
+				got = append(got, fmt.Sprintf("%d:%d", depth, n.Value()))
+			}
+			if !slices.Equal(got, tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTraversalsDegenerate(t *testing.T) {
+	// consecutive values degenerate a regular BST into a list, which would
+	// make recursive traversals use a lot of stack.
+	tr := NewBST[int]()
+	for i := range 5000 {
+		tr.Insert(i)
+	}
+
+	for name, seq := range map[string]iter.Seq2[int, Node[int]]{
+		"preorder":   PreOrder(tr.Root()),
+		"postorder":  PostOrder(tr.Root()),
+		"levelorder": LevelOrder(tr.Root()),
+	} {
+		maxDepth := 0
+		for depth := range seq {
+			maxDepth = max(maxDepth, depth)
+		}
+		if maxDepth != 4999 {
+			t.Errorf("%s: expected max depth 4999, got %d", name, maxDepth)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+)
+
+type Treap[T cmp.Ordered] struct {
+	root *TreapNode[T]
+	size int
+	rnd  *rand.Rand
+}
+
+// NewTreap returns an initialized treap whose node priorities are drawn from a
+// randomly seeded source.
+func NewTreap[T cmp.Ordered]() *Treap[T] {
+	return NewTreapWithSource[T](rand.NewSource(rand.Int63()))
+}
+
+// NewTreapWithSource returns an initialized treap whose node priorities are
+// drawn from src. Two treaps built from sources with the same seed and the same
+// sequence of operations will have identical shapes.
+func NewTreapWithSource[T cmp.Ordered](src rand.Source) *Treap[T] {
+	return &Treap[T]{
+		size: 0,
+		root: nil,
+		rnd:  rand.New(src),
+	}
+}
+
+func (t *Treap[T]) Root() Node[T] {
+	panicIfNilTree(t)
+
+	if t.root == nil {
+		return nil
+	}
+	return t.root
+}
+
+func (t *Treap[T]) Size() int {
+	panicIfNilTree(t)
+
+	return t.size
+}
+
+func (t *Treap[T]) Count(value T) int {
+	panicIfNilTree(t)
+
+	c := t.root
+	for c != nil {
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return c.Count()
+		}
+	}
+	return 0
+}
+
+func (t *Treap[T]) Insert(value T) error {
+	panicIfNilTree(t)
+
+	var y *TreapNode[T] = nil
+	c := t.root
+	for c != nil {
+		y = c
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return errors.New("value already exists")
+		}
+	}
+
+	z := &TreapNode[T]{
+		parent:   y,
+		value:    value,
+		priority: t.rnd.Int63(),
+	}
+	if y == nil {
+		t.root = z
+	} else if value < y.value {
+		y.left = z
+	} else {
+		y.right = z
+	}
+
+	// restore heap order by moving z up until its parent has a higher
+	// priority.
+	for z.parent != nil && z.parent.priority < z.priority {
+		if z == z.parent.left {
+			treapRightRotate(t, z.parent)
+		} else {
+			treapLeftRotate(t, z.parent)
+		}
+	}
+
+	t.size++
+	return nil
+}
+
+func (t *Treap[T]) Delete(value T) error {
+	panicIfNilTree(t)
+
+	// find z
+	z := t.root
+	for z != nil {
+		if value < z.value {
+			z = z.left
+		} else if value > z.value {
+			z = z.right
+		} else {
+			break
+		}
+	}
+	if z == nil {
+		return errors.New("value not found")
+	}
+
+	// move z down until it has at most one child, always rotating the child
+	// with the higher priority above it to keep heap order.
+	for z.left != nil && z.right != nil {
+		if z.left.priority > z.right.priority {
+			treapRightRotate(t, z)
+		} else {
+			treapLeftRotate(t, z)
+		}
+	}
+
+	if z.left == nil {
+		treaptransplant(t, z, z.right)
+	} else {
+		treaptransplant(t, z, z.left)
+	}
+
+	t.size--
+	return nil
+}
+
+func (t *Treap[T]) String() string {
+	panicIfNilTree(t)
+
+	return FormatTree(t, string(FormatHorizontal))
+}
+
+type TreapNode[T cmp.Ordered] struct {
+	parent   *TreapNode[T]
+	left     *TreapNode[T]
+	right    *TreapNode[T]
+	value    T
+	priority int64
+}
+
+func (n *TreapNode[T]) Parent() Node[T] {
+	panicIfNilNode(n)
+
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *TreapNode[T]) Left() Node[T] {
+	panicIfNilNode(n)
+
+	if n.left == nil {
+		return nil
+	}
+	return n.left
+}
+
+func (n *TreapNode[T]) Right() Node[T] {
+	panicIfNilNode(n)
+
+	if n.right == nil {
+		return nil
+	}
+	return n.right
+}
+
+func (n *TreapNode[T]) Value() T {
+	panicIfNilNode(n)
+
+	return n.value
+}
+
+func (n *TreapNode[T]) Count() int {
+	panicIfNilNode(n)
+
+	return 1
+}
+
+// Tree helpers
+
+func treapLeftRotate[T cmp.Ordered](t *Treap[T], x *TreapNode[T]) {
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	y.parent = x.parent
+	if x.parent == nil {
+		t.root = y
+	} else if x == x.parent.left {
+		x.parent.left = y
+	} else {
+		x.parent.right = y
+	}
+	y.left = x
+	x.parent = y
+}
+
+func treapRightRotate[T cmp.Ordered](t *Treap[T], y *TreapNode[T]) {
+	x := y.left
+	y.left = x.right
+	if x.right != nil {
+		x.right.parent = y
+	}
+	x.parent = y.parent
+	if y.parent == nil {
+		t.root = x
+	} else if y == y.parent.left {
+		y.parent.left = x
+	} else {
+		y.parent.right = x
+	}
+	x.right = y
+	y.parent = x
+}
+
+// transplant replaces one subtree with another subtree
+func treaptransplant[T cmp.Ordered](t *Treap[T], u *TreapNode[T], v *TreapNode[T]) {
+	// u is root
+	if u.parent == nil {
+		t.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
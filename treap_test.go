@@ -0,0 +1,51 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkTreap verifies parent links and the heap order of priorities of the
+// subtree rooted at n.
+func checkTreap(t *testing.T, n *TreapNode[int]) {
+	t.Helper()
+
+	if n == nil {
+		return
+	}
+	for _, c := range []*TreapNode[int]{n.left, n.right} {
+		if c == nil {
+			continue
+		}
+		if c.parent != n {
+			t.Fatalf("broken parent link on child %d of %d", c.value, n.value)
+		}
+		if c.priority > n.priority {
+			t.Fatalf("child %d has a higher priority than its parent %d", c.value, n.value)
+		}
+		checkTreap(t, c)
+	}
+}
+
+func TestTreapDeterministic(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	values := r.Perm(200)
+
+	t1 := NewTreapWithSource[int](rand.NewSource(1))
+	t2 := NewTreapWithSource[int](rand.NewSource(1))
+	for _, v := range values {
+		t1.Insert(v)
+		t2.Insert(v)
+		checkTreap(t, t1.root)
+	}
+	for _, v := range values[:100] {
+		t1.Delete(v)
+		t2.Delete(v)
+		checkTreap(t, t1.root)
+	}
+
+	if !Equal[int](t1, t2) {
+		t.Errorf("treaps with the same seed have different shapes\nt1:\n%s\nt2:\n%s\n",
+			FormatTree[int](t1, FormatLinuxTree), FormatTree[int](t2, FormatLinuxTree))
+	}
+}
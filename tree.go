@@ -1,17 +1,22 @@
 package tree
 
-import "cmp"
-
 // Tree represents the possible operations on binary search trees. Various tree
 // types (e.g. regular BST, balanced BST, red black tree etc.) implement this
 // interface.
 //
+// The element type of a tree is not constrained, since implementations decide
+// how values are ordered. Trees of cmp.Ordered values typically use the natural
+// ordering, while trees created with a Func constructor (e.g. NewBSTFunc) use
+// the comparison function provided by the caller.
+//
 // Calling any method on a nil tree should panic.
-type Tree[T cmp.Ordered] interface {
+type Tree[T any] interface {
 	// Retrieve the Root of this tree. Returns nil for a tree that had no nodes
 	// inserted to it.
 	Root() Node[T]
-	// Return the number of nodes in the tree.
+	// Return the number of elements in the tree. For implementations that
+	// allow storing multiple values of the same type, every occurence counts
+	// towards the size.
 	Size() int
 	// Count returns the number of elements with value `value` present in the
 	// tree. Implementations that require unique values will either return 0
@@ -45,7 +50,7 @@ type Tree[T cmp.Ordered] interface {
 // node's concrete type.
 //
 // Calling any method on a nil node should panic.
-type Node[T cmp.Ordered] interface {
+type Node[T any] interface {
 	// Value returns the value stored in the node.
 	Value() T
 	// Count returns the number of elements equal to the node's `value` are
@@ -67,3 +72,22 @@ type Node[T cmp.Ordered] interface {
 	// is no right child.
 	Right() Node[T]
 }
+
+// SizedNode is implemented by nodes that keep track of the size of the subtree
+// rooted at them, which allows order statistic queries without visiting the
+// whole tree.
+type SizedNode[T any] interface {
+	Node[T]
+	// SubtreeSize returns the number of elements stored in the subtree rooted
+	// at this node, including the node itself.
+	SubtreeSize() int
+}
+
+// TreeFunc is an alias of Tree, meant to be used with trees that order their
+// elements with a comparison function, such as the ones returned by NewBSTFunc
+// and NewRBTFunc. Since Tree doesn't constrain its element type, these trees
+// also work with every function accepting a Tree.
+type TreeFunc[T any] = Tree[T]
+
+// NodeFunc is an alias of Node, see TreeFunc.
+type NodeFunc[T any] = Node[T]
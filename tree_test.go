@@ -1,13 +1,41 @@
 package tree
 
 import (
+	"cmp"
+	"fmt"
 	"math/rand"
+	"strings"
 	"testing"
 )
 
-func TestInsertDeletes(t *testing.T) {
-	r := rand.New(rand.NewSource(69))
+// checkParentLinks verifies that every node below n is reachable back through
+// Parent().
+func checkParentLinks[T any](t *testing.T, n Node[T]) {
+	t.Helper()
+
+	if n == nil {
+		return
+	}
+	for _, c := range []Node[T]{n.Left(), n.Right()} {
+		if c == nil {
+			continue
+		}
+		if c.Parent() != n {
+			t.Fatalf("broken parent link on child %v of %v", c.Value(), n.Value())
+		}
+		checkParentLinks(t, c)
+	}
+}
+
+// height returns the number of nodes on the longest path from n to a leaf.
+func height[T any](n Node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return 1 + max(height(n.Left()), height(n.Right()))
+}
 
+func TestInsertDeletes(t *testing.T) {
 	type testcase struct {
 		name string
 		tree Tree[int]
@@ -22,6 +50,34 @@ func TestInsertDeletes(t *testing.T) {
 			name: "rbt",
 			tree: NewRBT[int](),
 		},
+		{
+			name: "avl",
+			tree: NewAVL[int](),
+		},
+		{
+			name: "treap",
+			tree: NewTreapWithSource[int](rand.NewSource(69)),
+		},
+		{
+			name: "splay",
+			tree: NewSplayTree[int](),
+		},
+		{
+			name: "scapegoat",
+			tree: NewScapegoat[int](),
+		},
+		{
+			name: "llrb",
+			tree: NewLLRB[int](),
+		},
+		{
+			name: "aa",
+			tree: NewAATree[int](),
+		},
+		{
+			name: "wbt",
+			tree: NewWBT[int](),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -29,6 +85,9 @@ func TestInsertDeletes(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
+			// subtests run in parallel, so each needs its own source.
+			r := rand.New(rand.NewSource(69))
+
 			// check that the tree works as a new one once everything was deleted
 			for range 2 {
 				existing := map[int]struct{}{}
@@ -77,3 +136,226 @@ func TestInsertDeletes(t *testing.T) {
 
 	}
 }
+
+func TestMultiset(t *testing.T) {
+	type multiset interface {
+		Tree[int]
+		Distinct() int
+	}
+
+	type testcase struct {
+		name string
+		tree multiset
+	}
+
+	testcases := []testcase{
+		{
+			name: "bst",
+			tree: NewMultiBST[int](),
+		},
+		{
+			name: "rbt",
+			tree: NewMultiRBT[int](),
+		},
+		{
+			name: "bstfunc",
+			tree: NewMultiBSTFunc(cmp.Compare[int]),
+		},
+		{
+			name: "rbtfunc",
+			tree: NewMultiRBTFunc(cmp.Compare[int]),
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			for v := range 10 {
+				for range v + 1 {
+					if err := tc.tree.Insert(v); err != nil {
+						t.Fatalf("expected duplicate insert of %d to succeed, got error %s", v, err.Error())
+					}
+				}
+			}
+
+			if tc.tree.Size() != 55 {
+				t.Errorf("expected 55 elements, got %d", tc.tree.Size())
+			}
+			if tc.tree.Distinct() != 10 {
+				t.Errorf("expected 10 distinct elements, got %d", tc.tree.Distinct())
+			}
+			for v := range 10 {
+				if c := tc.tree.Count(v); c != v+1 {
+					t.Errorf("expected count %d for %d, got %d", v+1, v, c)
+				}
+			}
+
+			// deleting decrements the count before removing the node.
+			tc.tree.Delete(9)
+			if c := tc.tree.Count(9); c != 9 {
+				t.Errorf("expected count 9 for 9 after one deletion, got %d", c)
+			}
+			if tc.tree.Size() != 54 || tc.tree.Distinct() != 10 {
+				t.Errorf("expected 54 elements and 10 distinct elements, got %d and %d", tc.tree.Size(), tc.tree.Distinct())
+			}
+			if err := tc.tree.Delete(0); err != nil {
+				t.Fatalf("expected 0 to be in tree, got error %s", err.Error())
+			}
+			if tc.tree.Count(0) != 0 || tc.tree.Distinct() != 9 {
+				t.Errorf("expected 0 to be removed from the tree")
+			}
+			if err := tc.tree.Delete(0); err == nil {
+				t.Errorf("expected error when deleting a missing value")
+			}
+		})
+	}
+}
+
+func TestFuncTrees(t *testing.T) {
+	type point struct {
+		x, y int
+	}
+	comparePoints := func(a, b point) int {
+		if c := cmp.Compare(a.x, b.x); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.y, b.y)
+	}
+
+	type testcase struct {
+		name    string
+		newTree func() TreeFunc[point]
+	}
+
+	testcases := []testcase{
+		{
+			name:    "bst",
+			newTree: func() TreeFunc[point] { return NewBSTFunc(comparePoints) },
+		},
+		{
+			name:    "rbt",
+			newTree: func() TreeFunc[point] { return NewRBTFunc(comparePoints) },
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := rand.New(rand.NewSource(23))
+			t1 := tc.newTree()
+			t2 := tc.newTree()
+			for _, v := range r.Perm(100) {
+				p := point{x: v % 10, y: v / 10}
+				if err := t1.Insert(p); err != nil {
+					t.Fatalf("expected %v to be inserted, got error %s", p, err.Error())
+				}
+				t2.Insert(p)
+			}
+
+			if t1.Insert(point{x: 3, y: 4}) == nil {
+				t.Errorf("expected error when inserting an existing value")
+			}
+			if t1.Count(point{x: 3, y: 4}) != 1 || t1.Count(point{x: 3, y: 10}) != 0 {
+				t.Errorf("unexpected counts for composite keys")
+			}
+			if !EqualFunc(t1, t2, func(a, b point) bool { return a == b }) {
+				t.Errorf("trees built from the same insertions are not equal")
+			}
+
+			for x := range 10 {
+				if err := t1.Delete(point{x: x, y: x}); err != nil {
+					t.Fatalf("expected %v to be in tree, got error %s", point{x: x, y: x}, err.Error())
+				}
+			}
+			if t1.Size() != 90 {
+				t.Errorf("expected 90 elements, got %d", t1.Size())
+			}
+			checkParentLinks(t, t1.Root())
+			if out := FormatTree(t1, FormatLinuxTree); !strings.HasPrefix(out, fmt.Sprint(t1.Root().Value())) {
+				t.Errorf("unexpected formatted tree:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestMultiFuncTrees(t *testing.T) {
+	type event struct {
+		day  int
+		name string
+	}
+	// events are only ordered by day, so events on the same day are counted
+	// as occurences of the same value.
+	compareDays := func(a, b event) int {
+		return cmp.Compare(a.day, b.day)
+	}
+
+	trees := map[string]TreeFunc[event]{
+		"bst": NewMultiBSTFunc(compareDays),
+		"rbt": NewMultiRBTFunc(compareDays),
+	}
+	for name, tr := range trees {
+		for _, e := range []event{{3, "a"}, {1, "b"}, {3, "c"}, {2, "d"}, {3, "e"}} {
+			if err := tr.Insert(e); err != nil {
+				t.Fatalf("%s: expected %v to be inserted, got error %s", name, e, err.Error())
+			}
+		}
+		if tr.Size() != 5 || tr.Count(event{day: 3}) != 3 {
+			t.Errorf("%s: expected 5 elements with 3 on day 3, got %d and %d", name, tr.Size(), tr.Count(event{day: 3}))
+		}
+		tr.Delete(event{day: 3})
+		if tr.Count(event{day: 3}) != 2 {
+			t.Errorf("%s: expected deleting to decrement the count", name)
+		}
+		checkParentLinks(t, tr.Root())
+	}
+}
+
+func TestRBTNodeChildren(t *testing.T) {
+	tr := NewRBT[int]()
+	tr.Insert(2)
+	tr.Insert(1)
+
+	// the root has a left child, but no right child.
+	root := tr.Root()
+	if root.Left() == nil || root.Left().Value() != 1 {
+		t.Fatalf("expected 1 to be the left child of the root")
+	}
+	if root.Right() != nil {
+		t.Errorf("expected the root to have no right child")
+	}
+	if l := root.Left(); l.Left() != nil || l.Right() != nil {
+		t.Errorf("expected 1 to be a leaf")
+	}
+}
+
+func TestUninitializedTrees(t *testing.T) {
+	for name, tr := range map[string]Tree[int]{"bst": &BST[int]{}, "rbt": &RBT[int]{}} {
+		for op, fn := range map[string]func(){
+			"insert": func() { tr.Insert(1) },
+			"delete": func() { tr.Delete(1) },
+			"count":  func() { tr.Count(1) },
+		} {
+			func() {
+				defer func() {
+					if r := recover(); r != "uninitialized tree" {
+						t.Errorf("%s: expected %s to panic with uninitialized tree, got %v", name, op, r)
+					}
+				}()
+				fn()
+			}()
+		}
+		if tr.Size() != 0 || tr.Root() != nil {
+			t.Errorf("%s: expected the zero value to be empty", name)
+		}
+	}
+
+	// cloning doesn't need the tree to be initialized.
+	if c := (&BST[int]{}).Clone(); c.Size() != 0 {
+		t.Errorf("expected the clone of the zero value to be empty")
+	}
+	if c := (&RBT[int]{}).Clone(); c.Size() != 0 {
+		t.Errorf("expected the clone of the zero value to be empty")
+	}
+}
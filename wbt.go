@@ -0,0 +1,307 @@
+package tree
+
+import (
+	"cmp"
+	"errors"
+)
+
+// WBT is a weight-balanced tree, also known as a BB[alpha] tree. Every node
+// stores the size of the subtree rooted at it, and the tree is rebalanced
+// whenever the sizes of the two subtrees of a node differ by too large a
+// factor. Since subtree sizes are always available, nodes implement SizedNode.
+type WBT[T cmp.Ordered] struct {
+	root *WBTNode[T]
+	size int
+}
+
+// NewWBT returns an initialized weight-balanced tree.
+func NewWBT[T cmp.Ordered]() *WBT[T] {
+	return &WBT[T]{
+		size: 0,
+		root: nil,
+	}
+}
+
+func (t *WBT[T]) Root() Node[T] {
+	panicIfNilTree(t)
+
+	if t.root == nil {
+		return nil
+	}
+	return t.root
+}
+
+func (t *WBT[T]) Size() int {
+	panicIfNilTree(t)
+
+	return t.size
+}
+
+func (t *WBT[T]) Count(value T) int {
+	panicIfNilTree(t)
+
+	c := t.root
+	for c != nil {
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return c.Count()
+		}
+	}
+	return 0
+}
+
+func (t *WBT[T]) Insert(value T) error {
+	panicIfNilTree(t)
+
+	var y *WBTNode[T] = nil
+	c := t.root
+	for c != nil {
+		y = c
+		if value < c.value {
+			c = c.left
+		} else if value > c.value {
+			c = c.right
+		} else {
+			return errors.New("value already exists")
+		}
+	}
+
+	z := &WBTNode[T]{
+		parent: y,
+		value:  value,
+		size:   1,
+	}
+	if y == nil {
+		t.root = z
+	} else if value < y.value {
+		y.left = z
+	} else {
+		y.right = z
+	}
+
+	wbtRebalance(t, y)
+	t.size++
+	return nil
+}
+
+func (t *WBT[T]) Delete(value T) error {
+	panicIfNilTree(t)
+
+	// find z
+	z := t.root
+	for z != nil {
+		if value < z.value {
+			z = z.left
+		} else if value > z.value {
+			z = z.right
+		} else {
+			break
+		}
+	}
+	if z == nil {
+		return errors.New("value not found")
+	}
+
+	// p is the lowest node whose subtree size has changed.
+	var p *WBTNode[T]
+	if z.left == nil {
+		p = z.parent
+		wbttransplant(t, z, z.right)
+	} else if z.right == nil {
+		p = z.parent
+		wbttransplant(t, z, z.left)
+	} else {
+		y := treeMinimumWbt(z.right)
+		if y.parent == z {
+			p = y
+		} else {
+			p = y.parent
+			wbttransplant(t, y, y.right)
+			y.right = z.right
+			y.right.parent = y
+		}
+		wbttransplant(t, z, y)
+		y.left = z.left
+		y.left.parent = y
+	}
+
+	wbtRebalance(t, p)
+	t.size--
+	return nil
+}
+
+func (t *WBT[T]) String() string {
+	panicIfNilTree(t)
+
+	return FormatTree(t, string(FormatHorizontal))
+}
+
+type WBTNode[T cmp.Ordered] struct {
+	parent *WBTNode[T]
+	left   *WBTNode[T]
+	right  *WBTNode[T]
+	value  T
+	// size is the number of nodes of the subtree rooted at this node.
+	size int
+}
+
+func (n *WBTNode[T]) Parent() Node[T] {
+	panicIfNilNode(n)
+
+	if n.parent == nil {
+		return nil
+	}
+	return n.parent
+}
+
+func (n *WBTNode[T]) Left() Node[T] {
+	panicIfNilNode(n)
+
+	if n.left == nil {
+		return nil
+	}
+	return n.left
+}
+
+func (n *WBTNode[T]) Right() Node[T] {
+	panicIfNilNode(n)
+
+	if n.right == nil {
+		return nil
+	}
+	return n.right
+}
+
+func (n *WBTNode[T]) Value() T {
+	panicIfNilNode(n)
+
+	return n.value
+}
+
+func (n *WBTNode[T]) Count() int {
+	panicIfNilNode(n)
+
+	return 1
+}
+
+func (n *WBTNode[T]) SubtreeSize() int {
+	panicIfNilNode(n)
+
+	return n.size
+}
+
+// Tree helpers
+
+// Balance parameters, as proposed by Hirai and Yamamoto. A node is balanced
+// if the weight of neither subtree exceeds _WBT_DELTA times the weight of the
+// other one. _WBT_GAMMA decides between a single and a double rotation.
+const (
+	_WBT_DELTA = 3
+	_WBT_GAMMA = 2
+)
+
+// wbtWeight returns the weight of a subtree, which is its size plus one.
+func wbtWeight[T cmp.Ordered](n *WBTNode[T]) int {
+	if n == nil {
+		return 1
+	}
+	return n.size + 1
+}
+
+func wbtUpdate[T cmp.Ordered](n *WBTNode[T]) {
+	n.size = wbtWeight(n.left) + wbtWeight(n.right) - 1
+}
+
+// wbtRebalance walks from n up to the root, restoring subtree sizes and the
+// weight balance along the way.
+func wbtRebalance[T cmp.Ordered](t *WBT[T], n *WBTNode[T]) {
+	for n != nil {
+		wbtUpdate(n)
+		lw, rw := wbtWeight(n.left), wbtWeight(n.right)
+		if _WBT_DELTA*lw < rw {
+			if wbtWeight(n.right.left) >= _WBT_GAMMA*wbtWeight(n.right.right) {
+				wbtRightRotate(t, n.right)
+			}
+			n = wbtLeftRotate(t, n)
+		} else if _WBT_DELTA*rw < lw {
+			if wbtWeight(n.left.right) >= _WBT_GAMMA*wbtWeight(n.left.left) {
+				wbtLeftRotate(t, n.left)
+			}
+			n = wbtRightRotate(t, n)
+		}
+		n = n.parent
+	}
+}
+
+// wbtLeftRotate rotates x to the left and returns the node that took its
+// place.
+func wbtLeftRotate[T cmp.Ordered](t *WBT[T], x *WBTNode[T]) *WBTNode[T] {
+	y := x.right
+	x.right = y.left
+	if y.left != nil {
+		y.left.parent = x
+	}
+	y.parent = x.parent
+	if x.parent == nil {
+		t.root = y
+	} else if x == x.parent.left {
+		x.parent.left = y
+	} else {
+		x.parent.right = y
+	}
+	y.left = x
+	x.parent = y
+
+	wbtUpdate(x)
+	wbtUpdate(y)
+	return y
+}
+
+// wbtRightRotate rotates y to the right and returns the node that took its
+// place.
+func wbtRightRotate[T cmp.Ordered](t *WBT[T], y *WBTNode[T]) *WBTNode[T] {
+	x := y.left
+	y.left = x.right
+	if x.right != nil {
+		x.right.parent = y
+	}
+	x.parent = y.parent
+	if y.parent == nil {
+		t.root = x
+	} else if y == y.parent.left {
+		y.parent.left = x
+	} else {
+		y.parent.right = x
+	}
+	x.right = y
+	y.parent = x
+
+	wbtUpdate(y)
+	wbtUpdate(x)
+	return x
+}
+
+// transplant replaces one subtree with another subtree
+func wbttransplant[T cmp.Ordered](t *WBT[T], u *WBTNode[T], v *WBTNode[T]) {
+	// u is root
+	if u.parent == nil {
+		t.root = v
+	} else if u == u.parent.left {
+		u.parent.left = v
+	} else {
+		u.parent.right = v
+	}
+	if v != nil {
+		v.parent = u.parent
+	}
+}
+
+func treeMinimumWbt[T cmp.Ordered](x *WBTNode[T]) *WBTNode[T] {
+	for x.left != nil {
+		x = x.left
+	}
+	return x
+}
@@ -0,0 +1,49 @@
+package tree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkWBT verifies the stored sizes and the weight balance of the subtree
+// rooted at n, returning its size.
+func checkWBT(t *testing.T, n *WBTNode[int]) int {
+	t.Helper()
+
+	if n == nil {
+		return 0
+	}
+	ls := checkWBT(t, n.left)
+	rs := checkWBT(t, n.right)
+	if n.size != ls+rs+1 {
+		t.Fatalf("node %d stores size %d, expected %d", n.value, n.size, ls+rs+1)
+	}
+	if _WBT_DELTA*(ls+1) < rs+1 || _WBT_DELTA*(rs+1) < ls+1 {
+		t.Fatalf("node %d is unbalanced: left size %d, right size %d", n.value, ls, rs)
+	}
+	return n.size
+}
+
+func TestWBTBalanced(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	tr := NewWBT[int]()
+
+	// consecutive values degenerate a regular BST into a list.
+	for i := range 500 {
+		tr.Insert(i)
+		checkWBT(t, tr.root)
+	}
+	checkParentLinks[int](t, tr.Root())
+
+	if s := tr.Root().(SizedNode[int]).SubtreeSize(); s != tr.Size() {
+		t.Errorf("root subtree size %d differs from tree size %d", s, tr.Size())
+	}
+
+	for _, v := range r.Perm(500)[:400] {
+		if err := tr.Delete(v); err != nil {
+			t.Fatalf("expected %d to be in tree, got error %s", v, err.Error())
+		}
+		checkWBT(t, tr.root)
+	}
+	checkParentLinks[int](t, tr.Root())
+}